@@ -0,0 +1,277 @@
+/*
+Package boostcompat runs the random number distributions of Boost.Random on Mersenne Twisters of mtrand.
+
+boost::random::mt19937 and mt19937_64 generate the same sequences with mtrand.MT32 and mtrand.MT64,
+and boost::random::mt11213b is mtrand.MTEngine with mtrand.MT11213B.
+The distributions of Boost have their own algorithms, which are different from the ones of C++ standard libraries
+(see cppcompat), and this package reproduces them.
+
+	mt := mtrand.NewMT32()
+	mt.Init(42) // boost::random::mt19937 g(42);
+	g := boostcompat.NewMT19937(mt)
+	n := g.UniformInt(1, 6) // boost::random::uniform_int_distribution<>(1, 6)(g);
+	z := g.Normal(0, 1)     // boost::random::normal_distribution<>(0, 1)(g);
+
+The normal and exponential distributions are the Ziggurat methods of Boost 1.56 and later,
+with the checks by the chord and the tangent of a strip before exp() of the current Boost sources.
+The checks were added after 1.56; they decide the same way with exp() except where a bound and f(x) are
+within rounding errors, so the versions with and without them give the same numbers but in rare cases.
+The release that added the checks was not confirmed, as Boost was not available.
+
+Boost was not available to capture values, so the distributions are ported from the Boost sources
+and tested by their properties and moments, not by numbers generated by Boost.
+The Ziggurat tables are regenerated from their definition, not copied from the Boost headers; see zigtab.go.
+*/
+package boostcompat
+
+import (
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/internal/libm"
+)
+
+// Gen is a Boost.Random engine
+type Gen struct {
+	next func() uint64
+	bits uint // number of bits of a generated number; 32 or 64
+}
+
+// NewMT19937() uses MT32 as boost::random::mt19937
+func NewMT19937(mt *mtrand.MT32) *Gen {
+	return &Gen{next: func() uint64 { return uint64(mt.GenUint32()) }, bits: 32}
+}
+
+// NewMT19937_64() uses MT64 as boost::random::mt19937_64
+func NewMT19937_64(mt *mtrand.MT64) *Gen {
+	return &Gen{next: mt.GenUint64, bits: 64}
+}
+
+// NewMT11213B() uses MTEngine as boost::random::mt11213b; mt must have the parameters of mtrand.MT11213B
+func NewMT11213B(mt *mtrand.MTEngine) *Gen {
+	if mt.Params() != mtrand.MT11213B {
+		panic("boostcompat: the engine is not mt11213b")
+	}
+	return &Gen{next: mt.GenUint64, bits: 32}
+}
+
+// max() - min() of the engine
+func (g *Gen) brange() uint64 {
+	return ^uint64(0) >> (64 - g.bits)
+}
+
+//
+// uniform_int_distribution
+//
+
+// detail::generate_uniform_int(); an integer on [0, rng]
+func (g *Gen) uniformInt(rng uint64) uint64 {
+	brange := g.brange()
+	switch {
+	case rng == 0:
+		return 0
+
+	case brange == rng:
+		return g.next()
+
+	case brange < rng:
+		// concatenate several numbers of the engine, and reject the ones out of range
+		for {
+			var limit uint64
+			if rng == ^uint64(0) {
+				limit = rng / (brange + 1)
+				if rng%(brange+1) == brange {
+					limit++
+				}
+			} else {
+				limit = (rng + 1) / (brange + 1)
+			}
+
+			result, mult := uint64(0), uint64(1)
+			for mult <= limit {
+				result += g.next() * mult
+				if mult*brange == rng-mult+1 {
+					// the range is a power of the engine's range
+					return result
+				}
+				mult *= brange + 1
+			}
+
+			inc := g.uniformInt(rng / mult)
+			if ^uint64(0)/mult < inc {
+				continue // the multiplication would overflow
+			}
+			inc *= mult
+			result += inc
+			if result < inc || result > rng {
+				continue
+			}
+			return result
+		}
+	}
+
+	// brange > rng; divide the engine's range into buckets
+	var bucket uint64
+	if brange == ^uint64(0) {
+		bucket = brange / (rng + 1)
+		if brange%(rng+1) == rng {
+			bucket++
+		}
+	} else {
+		bucket = (brange + 1) / (rng + 1)
+	}
+	for {
+		if r := g.next() / bucket; r <= rng {
+			return r
+		}
+	}
+}
+
+// UniformInt() is boost::random::uniform_int_distribution<int>(a, b)
+func (g *Gen) UniformInt(a, b int32) int32 {
+	if a > b {
+		panic("boostcompat: a > b")
+	}
+	return a + int32(g.uniformInt(uint64(uint32(b)-uint32(a))))
+}
+
+// UniformInt64() is boost::random::uniform_int_distribution<long long>(a, b)
+func (g *Gen) UniformInt64(a, b int64) int64 {
+	if a > b {
+		panic("boostcompat: a > b")
+	}
+	return a + int64(g.uniformInt(uint64(b)-uint64(a)))
+}
+
+// UniformUint64() is boost::random::uniform_int_distribution<unsigned long long>(a, b)
+func (g *Gen) UniformUint64(a, b uint64) uint64 {
+	if a > b {
+		panic("boostcompat: a > b")
+	}
+	return a + g.uniformInt(b-a)
+}
+
+//
+// uniform_01 and uniform_real_distribution
+//
+
+// Uniform01() is boost::random::uniform_01<double>; a float on [0, 1)
+func (g *Gen) Uniform01() float64 {
+	factor := 1.0 / (float64(g.brange()) + 1.0)
+	for {
+		if r := float64(g.next()) * factor; r < 1.0 {
+			return r
+		}
+	}
+}
+
+// UniformReal() is boost::random::uniform_real_distribution<double>(a, b); a float on [a, b)
+func (g *Gen) UniformReal(a, b float64) float64 {
+	divisor := float64(g.brange()) + 1.0
+	for {
+		if r := float64(float64(g.next())/divisor*(b-a)) + a; r < b {
+			return r
+		}
+	}
+}
+
+//
+// normal_distribution and exponential_distribution
+//
+
+// detail::generate_int_float_pair<double, 8>(); a float on [0, 1) with 53-bit resolution, and an 8-bit integer
+func (g *Gen) intFloatPair() (float64, int) {
+	if g.bits == 64 {
+		u := g.next()
+		bucket := int(u & 0xff)
+		// Boost "zeros out unused bits" by u &= ~(base_unsigned(1) << (m - digits)), which clears the bit 11 only;
+		// the 56 bits left are rounded to a double by the conversion
+		u &^= 1 << (64 - 53)
+		return float64(u>>8) * (1.0 / (1 << 56)), bucket
+	}
+	u := g.next()
+	bucket := int(u & 0xff)
+	r := float64(float64(u>>8) * (1.0 / (1 << 24)))
+	r += float64(g.next() & (1<<29 - 1))
+	r *= 1.0 / (1 << 29)
+	return r, bucket
+}
+
+// detail::unit_normal_distribution
+func (g *Gen) unitNormal() float64 {
+	for {
+		u, i := g.intFloatPair()
+		sign := float64((i&1)*2 - 1)
+		i >>= 1
+		x := float64(u * normalX[i])
+		if x < normalX[i+1] {
+			return x * sign
+		}
+		if i == 0 {
+			return g.normalTail() * sign
+		}
+		y01 := g.Uniform01()
+		y := normalY[i] + float64(y01*(normalY[i+1]-normalY[i]))
+
+		// y minus the chord from (x[i], y[i]) to (x[i+1], y[i+1]), scaled, and y minus the tangent at x[i+1];
+		// f(x) is under the chord and over the tangent where x[i] >= 1, and the other way where x[i] < 1
+		chord := float64((normalX[i]-normalX[i+1])*y01) - (normalX[i] - x)
+		tangent := y - (normalY[i+1] + float64(float64(float64(normalX[i+1]-x)*normalY[i+1])*normalX[i+1]))
+		aboveUbound, aboveLbound := chord, tangent
+		if normalX[i] < 1 {
+			aboveUbound, aboveLbound = tangent, chord
+		}
+		if aboveUbound < 0 && (aboveLbound < 0 || y < libm.Exp(-(x*x/2))) {
+			return x * sign
+		}
+	}
+}
+
+// the tail beyond normalX[1], by the rejection from an exponential distribution
+func (g *Gen) normalTail() float64 {
+	tailStart := normalX[1]
+	for {
+		x := g.unitExponential() / tailStart
+		y := g.unitExponential()
+		if 2*y > x*x {
+			return x + tailStart
+		}
+	}
+}
+
+// Normal() is boost::random::normal_distribution<double>(mean, sigma)
+func (g *Gen) Normal(mean, sigma float64) float64 {
+	return float64(g.unitNormal()*sigma) + mean
+}
+
+// detail::unit_exponential_distribution
+func (g *Gen) unitExponential() float64 {
+	shift := 0.0
+	for {
+		u, i := g.intFloatPair()
+		x := float64(u * expX[i])
+		if x < expX[i+1] {
+			return shift + x
+		}
+		if i == 0 {
+			// the tail is the same with the whole distribution
+			shift += expX[1]
+			continue
+		}
+		y01 := g.Uniform01()
+		y := expY[i] + float64(y01*(expY[i+1]-expY[i]))
+
+		// f(x) is under the chord and over the tangent at x[i+1]
+		aboveUbound := float64((expX[i]-expX[i+1])*y01) - (expX[i] - x)
+		aboveLbound := y - (expY[i+1] + float64((expX[i+1]-x)*expY[i+1]))
+		if aboveUbound < 0 && (aboveLbound < 0 || y < libm.Exp(-x)) {
+			return x + shift
+		}
+	}
+}
+
+// Exponential() is boost::random::exponential_distribution<double>(lambda)
+func (g *Gen) Exponential(lambda float64) float64 {
+	if !(lambda > 0) {
+		panic("boostcompat: lambda must be positive")
+	}
+	return g.unitExponential() / lambda
+}
@@ -0,0 +1,90 @@
+package boostcompat_test
+
+import (
+	"math"
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/boostcompat"
+)
+
+// uniform_int_distribution divides the range of the engine into buckets
+func TestUniformInt(t *testing.T) {
+	mt1, mt2 := mtrand.NewMT32(), mtrand.NewMT32()
+	g := boostcompat.NewMT19937(mt1)
+	const bucket = (1 << 32) / 6
+	for i := 0; i < 1000; i++ {
+		v := uint64(mt2.GenUint32()) / bucket
+		for v > 5 {
+			v = uint64(mt2.GenUint32()) / bucket
+		}
+		if r := g.UniformInt(1, 6); r != int32(v)+1 {
+			t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v+1, r)
+		}
+	}
+
+	// the full range of 64 bits is two numbers of the engine, the first one is the lower half
+	for i := 0; i < 1000; i++ {
+		lo, hi := uint64(mt2.GenUint32()), uint64(mt2.GenUint32())
+		v := hi<<32 | lo
+		if r := g.UniformUint64(0, math.MaxUint64); r != v {
+			t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+	}
+
+	// ranges wider than the engine
+	for i := 0; i < 10000; i++ {
+		if r := g.UniformInt64(-3, 1<<40); r < -3 || r > 1<<40 {
+			t.Fatalf("out of range for iteration %d: %v", i, r)
+		}
+	}
+}
+
+// mt11213b generates the same sequence as MTEngine
+func TestMT11213B(t *testing.T) {
+	mt1, mt2 := mtrand.NewMTEngine(mtrand.MT11213B), mtrand.NewMTEngine(mtrand.MT11213B)
+	g := boostcompat.NewMT11213B(mt1)
+	for i := 0; i < 1000; i++ {
+		v := float64(mt2.GenUint64()) / 4294967296.0
+		if r := g.Uniform01(); r != v {
+			t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+	}
+}
+
+// sample moments of the Ziggurat methods
+func TestNormalExponential(t *testing.T) {
+	const n = 1000000
+	for _, g := range []*boostcompat.Gen{boostcompat.NewMT19937(mtrand.NewMT32()), boostcompat.NewMT19937_64(mtrand.NewMT64())} {
+		sum, sum2, tail := 0.0, 0.0, 0
+		for i := 0; i < n; i++ {
+			x := g.Normal(1, 2)
+			sum += x
+			sum2 += x * x
+			if math.Abs(x-1) > 2*3.4426198558990002 {
+				tail++
+			}
+		}
+		mean := sum / n
+		variance := sum2/n - mean*mean
+		if math.Abs(mean-1) > 0.01 || math.Abs(variance-4) > 0.04 {
+			t.Errorf("invalid normal distribution: mean %v, variance %v", mean, variance)
+		}
+		// P(|x| > r) = 5.76e-4
+		if tail < 480 || tail > 680 {
+			t.Errorf("invalid number of tail values: %d", tail)
+		}
+
+		sum, sum2 = 0, 0
+		for i := 0; i < n; i++ {
+			x := g.Exponential(2)
+			sum += x
+			sum2 += x * x
+		}
+		mean = sum / n
+		variance = sum2/n - mean*mean
+		if math.Abs(mean-0.5) > 0.005 || math.Abs(variance-0.25) > 0.005 {
+			t.Errorf("invalid exponential distribution: mean %v, variance %v", mean, variance)
+		}
+	}
+}
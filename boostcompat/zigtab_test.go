@@ -0,0 +1,37 @@
+package boostcompat
+
+import (
+	"math"
+	"testing"
+)
+
+// every strip of the Ziggurats has the same area
+func TestZigTab(t *testing.T) {
+	tabs := []struct {
+		name string
+		x, y []float64
+		f    func(float64) float64
+	}{
+		{"normal", normalX[:], normalY[:], func(x float64) float64 { return math.Exp(-x * x / 2) }},
+		{"exponential", expX[:], expY[:], func(x float64) float64 { return math.Exp(-x) }},
+	}
+	for _, tab := range tabs {
+		n := len(tab.x) - 1
+		area := tab.x[2] * (tab.y[3] - tab.y[2])
+		for i := 2; i < n; i++ { // x[1] of normal is rounded as Marsaglia and Tsang
+			if a := tab.x[i] * (tab.y[i+1] - tab.y[i]); math.Abs(a-area) > 1e-15 {
+				t.Errorf("%s: invalid area of strip %d: expected %v, actual %v", tab.name, i, area, a)
+			}
+			if v := tab.f(tab.x[i]); math.Abs(v-tab.y[i]) > 1e-15 {
+				t.Errorf("%s: invalid y[%d]: expected %v, actual %v", tab.name, i, v, tab.y[i])
+			}
+		}
+		if tab.x[n] != 0 || tab.y[n] != 1 || tab.y[0] != 0 {
+			t.Errorf("%s: invalid top of the table", tab.name)
+		}
+		// the base strip; a box under y[1] and the tail
+		if a := tab.x[0] * tab.y[1]; math.Abs(a-area) > 1e-11 {
+			t.Errorf("%s: invalid area of the base strip: expected %v, actual %v", tab.name, area, a)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+/*
+	bytestream.go
+	a byte stream of a generator that does not depend on the sizes of reads
+
+	Read() of the generators discards the unused bytes of the last word, so reading 3 and 3 bytes
+	gives different data from reading 6 bytes at once. ByteStream keeps the unused bytes for the next read.
+*/
+
+package mtrand
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// ByteStream is the bytes of the words of a generator, in a byte order
+type ByteStream struct {
+	next  func() uint64 // a word of the generator
+	size  int           // bytes of a word; 4 or 8
+	order binary.ByteOrder
+	word  [8]byte
+	pend  []byte // bytes generated but not read yet
+}
+
+var (
+	_ io.Reader     = (*ByteStream)(nil)
+	_ io.ByteReader = (*ByteStream)(nil)
+	_ io.WriterTo   = (*ByteStream)(nil)
+)
+
+// NewByteStream32() creates a ByteStream of 32-bit words, like mt.GenUint32 of MT32, in the order.
+// With binary.LittleEndian, the bytes are the same with MT32.Read() of multiples of 4 bytes.
+func NewByteStream32(gen func() uint32, order binary.ByteOrder) *ByteStream {
+	return &ByteStream{next: func() uint64 { return uint64(gen()) }, size: 4, order: order}
+}
+
+// NewByteStream64() creates a ByteStream of 64-bit words, like mt.GenUint64 of MT64, in the order.
+// With binary.LittleEndian, the bytes are the same with MT64.Read() of multiples of 8 bytes.
+func NewByteStream64(gen func() uint64, order binary.ByteOrder) *ByteStream {
+	return &ByteStream{next: gen, size: 8, order: order}
+}
+
+// put a word of the generator in b
+func (s *ByteStream) fill(b []byte) {
+	if s.size == 4 {
+		s.order.PutUint32(b, uint32(s.next()))
+	} else {
+		s.order.PutUint64(b, s.next())
+	}
+}
+
+// Read() fills buf, and never fails
+func (s *ByteStream) Read(buf []byte) (n int, err error) {
+	n = len(buf)
+	c := copy(buf, s.pend)
+	s.pend = s.pend[c:]
+	buf = buf[c:]
+	for len(buf) >= s.size {
+		s.fill(buf)
+		buf = buf[s.size:]
+	}
+	if len(buf) > 0 {
+		s.fill(s.word[:])
+		c = copy(buf, s.word[:s.size])
+		s.pend = s.word[c:s.size]
+	}
+	return n, nil
+}
+
+// ReadByte() reads a byte, and never fails
+func (s *ByteStream) ReadByte() (byte, error) {
+	if len(s.pend) == 0 {
+		s.fill(s.word[:])
+		s.pend = s.word[:s.size]
+	}
+	b := s.pend[0]
+	s.pend = s.pend[1:]
+	return b, nil
+}
+
+// WriteTo() writes the stream to w until w fails. The stream has no end, so it always returns the error of w.
+// The bytes not taken by w are kept for the next read.
+func (s *ByteStream) WriteTo(w io.Writer) (n int64, err error) {
+	buf := make([]byte, 4096)
+	for {
+		s.Read(buf)
+		m, err := w.Write(buf)
+		n += int64(m)
+		if err != nil || m < len(buf) {
+			if m < len(buf) {
+				s.pend = append(buf[m:len(buf):len(buf)], s.pend...)
+			}
+			if err == nil {
+				err = io.ErrShortWrite
+			}
+			return n, err
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package mtrand_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+)
+
+// the bytes do not depend on the sizes of reads
+func TestByteStream(t *testing.T) {
+	const n = 10000
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		ref := mtrand.NewMT64()
+		expected := make([]byte, n+8)
+		for i := 0; i < len(expected); i += 8 {
+			order.PutUint64(expected[i:], ref.GenUint64())
+		}
+
+		sizer := mtrand.NewMT32()
+		s := mtrand.NewByteStream64(mtrand.NewMT64().GenUint64, order)
+		var got []byte
+		for len(got) < n {
+			k := int(sizer.GenUint32() % 20)
+			if k == 0 {
+				b, _ := s.ReadByte()
+				got = append(got, b)
+				continue
+			}
+			buf := make([]byte, k)
+			if m, err := s.Read(buf); m != k || err != nil {
+				t.Fatalf("invalid read: %d, %v", m, err)
+			}
+			got = append(got, buf...)
+		}
+		if !bytes.Equal(got[:n], expected[:n]) {
+			t.Errorf("invalid bytes for %v", order)
+		}
+	}
+
+	// the same bytes with Read() of the generator for whole words
+	mt1, mt2 := mtrand.NewMT32(), mtrand.NewMT32()
+	s := mtrand.NewByteStream32(mt1.GenUint32, binary.LittleEndian)
+	b1, b2 := make([]byte, 400), make([]byte, 400)
+	s.Read(b1[:3])
+	s.Read(b1[3:])
+	mt2.Read(b2)
+	if !bytes.Equal(b1, b2) {
+		t.Errorf("invalid bytes: expected %x, actual %x", b2[:16], b1[:16])
+	}
+}
+
+// a writer that takes limited bytes
+type limitedWriter struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+var errLimit = errors.New("limit")
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if w.buf.Len()+len(p) > w.limit {
+		p = p[:w.limit-w.buf.Len()]
+		w.buf.Write(p)
+		return len(p), errLimit
+	}
+	return w.buf.Write(p)
+}
+
+// WriteTo() keeps the bytes not written
+func TestByteStreamWriteTo(t *testing.T) {
+	s1 := mtrand.NewByteStream32(mtrand.NewMT32().GenUint32, binary.BigEndian)
+	s2 := mtrand.NewByteStream32(mtrand.NewMT32().GenUint32, binary.BigEndian)
+	w := &limitedWriter{limit: 10001}
+	s1.Read(make([]byte, 5))
+	n, err := s1.WriteTo(w)
+	if n != 10001 || err != errLimit {
+		t.Fatalf("invalid WriteTo: %d, %v", n, err)
+	}
+	rest := make([]byte, 5000)
+	s1.Read(rest)
+
+	expected := make([]byte, 5+10001+5000)
+	s2.Read(expected)
+	actual := append(append([]byte(nil), w.buf.Bytes()...), rest...)
+	if !bytes.Equal(actual, expected[5:]) {
+		t.Errorf("invalid bytes after WriteTo")
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Package commonscompat reproduces MersenneTwister of Apache Commons Math 3 on a Mersenne Twister of mtrand,
+and reads and writes saved states of MT and MT_64 of Apache Commons RNG.
+
+MersenneTwister of Commons Math is MT19937, the same generator with mtrand.MT32,
+and MersenneTwister in this package generates the same numbers with Java for the same seed.
+Java's int and long are int32 and int64 here.
+
+	r := commonscompat.New(mtrand.NewMT32())
+	r.SetSeedLong(1234)   // r = new MersenneTwister(1234L)
+	x := r.NextDouble()   // r.nextDouble()
+	n := r.NextIntN(6)    // r.nextInt(6)
+
+Java was not available to capture values, so the generator is tested by the mt19937ar output used by the tests of Commons Math,
+the other methods by their algorithms in the Commons Math sources, and the saved states by round trips,
+not by states saved by Commons RNG.
+
+Like math/rand, invalid arguments cause a panic, where Java throws an exception.
+*/
+package commonscompat
+
+import (
+	"math"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/internal/libm"
+)
+
+const n = 624
+
+// MersenneTwister is a random number generator with the algorithms of org.apache.commons.math3.random.MersenneTwister
+type MersenneTwister struct {
+	mt           *mtrand.MT32
+	nextGaussian float64 // the second number of NextGaussian()
+	hasGaussian  bool    // true if nextGaussian is valid; nextGaussian is not NaN
+}
+
+// New() creates a MersenneTwister on mt. The state of mt is used as is until one of seeding functions is called.
+func New(mt *mtrand.MT32) *MersenneTwister {
+	return &MersenneTwister{mt: mt}
+}
+
+// MT32() returns the underlying generator
+func (r *MersenneTwister) MT32() *mtrand.MT32 {
+	return r.mt
+}
+
+//
+// seeding
+//
+
+// SetSeedInt() is setSeed(int).
+// The seed is sign-extended in the first step, so a negative seed differs from MT32.Init().
+func (r *MersenneTwister) SetSeedInt(seed int32) {
+	state := make([]uint32, n)
+	l := int64(seed) // a long masked by 0xffffffff as a poor man unsigned int
+	state[0] = uint32(l)
+	for i := 1; i < n; i++ {
+		l = (1812433253*(l^(l>>30)) + int64(i)) & 0xffff_ffff
+		state[i] = uint32(l)
+	}
+	r.mt.InitByState(state, n)
+	r.hasGaussian = false
+}
+
+// SetSeedInts() is setSeed(int[]), the same with MT32.InitByArray()
+func (r *MersenneTwister) SetSeedInts(seed []int32) {
+	if len(seed) == 0 {
+		panic("commonscompat: empty seed")
+	}
+	key := make([]uint32, len(seed))
+	for i, v := range seed {
+		key[i] = uint32(v)
+	}
+	r.mt.InitByArray(key)
+	r.hasGaussian = false
+}
+
+// SetSeedLong() is setSeed(long); setSeed(int[]) with the upper and the lower 32 bits
+func (r *MersenneTwister) SetSeedLong(seed int64) {
+	r.SetSeedInts([]int32{int32(seed >> 32), int32(seed)})
+}
+
+//
+// integers
+//
+
+// next(bits); the upper bits of a 32-bit number
+func (r *MersenneTwister) next(bits uint) uint32 {
+	return r.mt.GenUint32() >> (32 - bits)
+}
+
+// NextInt() is nextInt()
+func (r *MersenneTwister) NextInt() int32 {
+	return int32(r.next(32))
+}
+
+// NextIntN() is nextInt(n); a number on [0, n)
+func (r *MersenneTwister) NextIntN(bound int32) int32 {
+	if bound <= 0 {
+		panic("commonscompat: n must be strictly positive")
+	}
+	if bound&-bound == bound { // a power of 2
+		return int32((int64(bound) * int64(r.next(31))) >> 31)
+	}
+	for {
+		bits := int32(r.next(31))
+		val := bits % bound
+		if bits-val+(bound-1) >= 0 { // reject the numbers beyond the last multiple of bound, by int overflow
+			return val
+		}
+	}
+}
+
+// NextLong() is nextLong()
+func (r *MersenneTwister) NextLong() int64 {
+	high := int64(r.next(32)) << 32
+	low := int64(r.next(32))
+	return high | low
+}
+
+// NextLongN() is nextLong(n); a number on [0, n)
+func (r *MersenneTwister) NextLongN(bound int64) int64 {
+	if bound <= 0 {
+		panic("commonscompat: n must be strictly positive")
+	}
+	for {
+		bits := int64(r.next(31))<<32 | int64(r.next(32))
+		val := bits % bound
+		if bits-val+(bound-1) >= 0 {
+			return val
+		}
+	}
+}
+
+// NextBoolean() is nextBoolean()
+func (r *MersenneTwister) NextBoolean() bool {
+	return r.next(1) != 0
+}
+
+//
+// floats
+//
+
+// NextDouble() is nextDouble(); a number on [0, 1) with 52-bit resolution
+func (r *MersenneTwister) NextDouble() float64 {
+	high := uint64(r.next(26)) << 26
+	low := uint64(r.next(26))
+	return float64(high|low) * 0x1p-52
+}
+
+// NextFloat() is nextFloat(); a number on [0, 1) with 23-bit resolution
+func (r *MersenneTwister) NextFloat() float32 {
+	return float32(r.next(23)) * 0x1p-23
+}
+
+// NextGaussian() is nextGaussian(), by the Box-Muller transform.
+// The second number of a pair is kept for the next call, as Commons Math does.
+// Commons Math uses FastMath, which is not always correctly rounded; results may differ in the last bit in rare cases.
+func (r *MersenneTwister) NextGaussian() float64 {
+	if r.hasGaussian {
+		r.hasGaussian = false
+		return r.nextGaussian
+	}
+	x := r.NextDouble()
+	y := r.NextDouble()
+	alpha := 2 * math.Pi * x
+	rad := math.Sqrt(-2 * libm.Log(y))
+	r.nextGaussian, r.hasGaussian = rad*libm.Sin(alpha), true
+	return rad * libm.Cos(alpha)
+}
+
+//
+// bytes
+//
+
+// NextBytes() is nextBytes(bytes) of Commons Math 3.6; little endian 32-bit numbers, and the last one is truncated.
+// Commons Math 3.5 and before draw one more number, which is not used, when len(b) is a multiple of 4.
+func (r *MersenneTwister) NextBytes(b []byte) {
+	for i := 0; i < len(b); i += 4 {
+		v := r.next(32)
+		for j := i; j < i+4 && j < len(b); j++ {
+			b[j] = byte(v)
+			v >>= 8
+		}
+	}
+}
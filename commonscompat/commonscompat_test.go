@@ -0,0 +1,155 @@
+package commonscompat_test
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/commonscompat"
+)
+
+func TestMersenneTwister(t *testing.T) {
+	r := commonscompat.New(mtrand.NewMT32())
+
+	// testMakotoNishimura of Commons Math; the output of mt19937ar.c
+	r.SetSeedInts([]int32{0x123, 0x234, 0x345, 0x456})
+	target1 := []uint32{1067595299, 955945823, 477289528, 4107218783, 4228976476}
+	for i, v := range target1 {
+		if x := r.NextInt(); x != int32(v) {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, int32(v), x)
+		}
+	}
+
+	// setSeed(int) is init_genrand() for a non-negative seed
+	mt := mtrand.NewMT32()
+	r.SetSeedInt(1)
+	mt.Init(1)
+	for i := 0; i < 10; i++ {
+		if x, v := r.NextInt(), int32(mt.GenUint32()); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+
+	// a negative seed is sign-extended in the first step
+	r.SetSeedInt(-1)
+	state, _ := r.MT32().State()
+	if state[0] != 0xffff_ffff || state[1] != 1 { // -1 ^ (-1 >> 30) is 0 in Java, but 3 in unsigned
+		t.Errorf("invalid state for a negative seed: %x %x", state[0], state[1])
+	}
+
+	// setSeed(long) is setSeed(int[]{high, low})
+	r.SetSeedLong(0x1234_5678_9abc_def0)
+	mt.InitByArray([]uint32{0x1234_5678, 0x9abc_def0})
+	for i := 0; i < 10; i++ {
+		if x, v := r.NextInt(), int32(mt.GenUint32()); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+}
+
+func TestMersenneTwisterBits(t *testing.T) {
+	r := commonscompat.New(mtrand.NewMT32())
+	mt := mtrand.NewMT32()
+	r.SetSeedInt(5)
+	mt.Init(5)
+
+	// nextInt(n) of a power of 2 takes the upper bits
+	if x, v := r.NextIntN(8), int32(mt.GenUint32()>>29); x != v {
+		t.Errorf("invalid nextInt(8): expected %v, actual %v", v, x)
+	}
+	// nextInt(n) takes 31 bits and rejects by overflow
+	for i := 0; i < 10; i++ {
+		var v int32
+		for {
+			bits := int32(mt.GenUint32() >> 1)
+			v = bits % 1000000000
+			if bits-v+999999999 >= 0 {
+				break
+			}
+		}
+		if x := r.NextIntN(1000000000); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+	// nextLong()
+	hi, lo := uint64(mt.GenUint32()), uint64(mt.GenUint32())
+	if x := r.NextLong(); uint64(x) != hi<<32|lo {
+		t.Errorf("invalid nextLong: expected %x, actual %x", hi<<32|lo, x)
+	}
+	// nextDouble() takes 26 bits twice
+	a, b := mt.GenUint32()>>6, mt.GenUint32()>>6
+	if x, v := r.NextDouble(), float64(uint64(a)<<26|uint64(b))/(1<<52); x != v {
+		t.Errorf("invalid nextDouble: expected %v, actual %v", v, x)
+	}
+	// nextBytes()
+	buf := make([]byte, 6)
+	r.NextBytes(buf)
+	v0, v1 := mt.GenUint32(), mt.GenUint32()
+	if expected := []byte{byte(v0), byte(v0 >> 8), byte(v0 >> 16), byte(v0 >> 24), byte(v1), byte(v1 >> 8)}; !reflect.DeepEqual(buf, expected) {
+		t.Errorf("invalid nextBytes: expected %x, actual %x", expected, buf)
+	}
+
+	// nextGaussian() keeps the second number of a pair
+	r.SetSeedInt(7)
+	const n = 100000
+	sum, sum2 := 0.0, 0.0
+	for i := 0; i < n; i++ {
+		x := r.NextGaussian()
+		sum += x
+		sum2 += x * x
+	}
+	if mean, variance := sum/n, sum2/n-(sum/n)*(sum/n); math.Abs(mean) > 0.02 || math.Abs(variance-1) > 0.03 {
+		t.Errorf("invalid distribution: mean %v, variance %v", mean, variance)
+	}
+	r.SetSeedInt(7)
+	mt.Init(7)
+	r.NextGaussian()
+	r.NextGaussian() // the second one does not consume numbers
+	mt.GenUint32()
+	mt.GenUint32()
+	mt.GenUint32()
+	mt.GenUint32()
+	if x, v := r.NextInt(), int32(mt.GenUint32()); x != v {
+		t.Errorf("invalid value after nextGaussian: expected %v, actual %v", v, x)
+	}
+}
+
+func TestState(t *testing.T) {
+	// MT; 625 ints and the cache of IntProvider
+	mt := mtrand.NewMT32()
+	mt.Init(1)
+	for i := 0; i < 100; i++ {
+		mt.GenUint32()
+	}
+	b := commonscompat.SaveStateMT(mt)
+	if len(b) != 625*4+8 || binary.LittleEndian.Uint32(b[624*4:]) != 100 {
+		t.Errorf("invalid state of MT: length %d", len(b))
+	}
+	mt2 := mtrand.NewMT32()
+	commonscompat.RestoreStateMT(mt2, b[:625*4]) // a state of Commons RNG 1.2
+	for i := 0; i < 1000; i++ {
+		if x, v := mt2.GenUint32(), mt.GenUint32(); x != v {
+			t.Errorf("invalid value for iteration %d: expected %x, actual %x", i, v, x)
+			break
+		}
+	}
+
+	// MT_64; 313 longs and the cache of LongProvider
+	m64 := mtrand.NewMT64()
+	b = commonscompat.SaveStateMT64(m64)
+	if len(b) != 313*8+32 || binary.LittleEndian.Uint64(b[312*8:]) != 312 {
+		t.Errorf("invalid state of MT_64: length %d", len(b))
+	}
+	m64b := mtrand.NewMT64()
+	commonscompat.RestoreStateMT64(m64b, b)
+	ref := mtrand.NewMT64()
+	ref.Init(5489)
+	for i := 0; i < 1000; i++ {
+		if x, v := m64b.GenUint64(), ref.GenUint64(); x != v {
+			t.Errorf("invalid value for iteration %d: expected %x, actual %x", i, v, x)
+			break
+		}
+	}
+}
@@ -0,0 +1,90 @@
+/*
+	state.go
+	saved states of RandomSource.MT and RandomSource.MT_64 of Commons RNG
+
+	A state of saveState() is the state words followed by the index, in little endian,
+	and then the state of the caches of IntProvider or LongProvider since Commons RNG 1.3.
+	The caches are for nextBoolean() and nextInt() of a 64-bit generator; they are saved empty,
+	and ignored on restoring.
+*/
+
+package commonscompat
+
+import (
+	"encoding/binary"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+)
+
+const (
+	mt64N = 312
+
+	intProviderSize  = 2 * 4 // booleanSource and booleanBitMask
+	longProviderSize = 4 * 8 // booleanSource, booleanBitMask, intSource and cachedIntSource
+)
+
+// SaveStateMT() returns the state of mt in the format of saveState() of RandomSource.MT.
+// An uninitialized mt is initialized by the default seed first, as it would be on the next output.
+func SaveStateMT(mt *mtrand.MT32) []byte {
+	state, i := mt.State()
+	if i > n {
+		mt.Init(5489)
+		state, i = mt.State()
+	}
+	b := make([]byte, (n+1)*4+intProviderSize)
+	for j, v := range state {
+		binary.LittleEndian.PutUint32(b[4*j:], v)
+	}
+	binary.LittleEndian.PutUint32(b[4*n:], uint32(i))
+	return b
+}
+
+// RestoreStateMT() sets a state of RandomSource.MT to mt.
+// It accepts states of Commons RNG 1.0 to 1.2, without the cache, and of 1.3 and later.
+func RestoreStateMT(mt *mtrand.MT32, b []byte) {
+	if len(b) != (n+1)*4 && len(b) != (n+1)*4+intProviderSize {
+		panic("commonscompat: invalid length of a state of MT")
+	}
+	state := make([]uint32, n)
+	for j := range state {
+		state[j] = binary.LittleEndian.Uint32(b[4*j:])
+	}
+	i := int32(binary.LittleEndian.Uint32(b[4*n:]))
+	if i < 0 || i > n {
+		panic("commonscompat: invalid index in a state of MT")
+	}
+	mt.InitByState(state, int(i))
+}
+
+// SaveStateMT64() returns the state of mt in the format of saveState() of RandomSource.MT_64.
+// An uninitialized mt is initialized by the default seed first, as it would be on the next output.
+func SaveStateMT64(mt *mtrand.MT64) []byte {
+	state, i := mt.State()
+	if i > mt64N {
+		mt.Init(5489)
+		state, i = mt.State()
+	}
+	b := make([]byte, (mt64N+1)*8+longProviderSize)
+	for j, v := range state {
+		binary.LittleEndian.PutUint64(b[8*j:], v)
+	}
+	binary.LittleEndian.PutUint64(b[8*mt64N:], uint64(i))
+	return b
+}
+
+// RestoreStateMT64() sets a state of RandomSource.MT_64 to mt.
+// It accepts states of Commons RNG 1.0 to 1.2, without the cache, and of 1.3 and later.
+func RestoreStateMT64(mt *mtrand.MT64, b []byte) {
+	if len(b) != (mt64N+1)*8 && len(b) != (mt64N+1)*8+longProviderSize {
+		panic("commonscompat: invalid length of a state of MT_64")
+	}
+	state := make([]uint64, mt64N)
+	for j := range state {
+		state[j] = binary.LittleEndian.Uint64(b[8*j:])
+	}
+	i := int64(binary.LittleEndian.Uint64(b[8*mt64N:]))
+	if i < 0 || i > mt64N {
+		panic("commonscompat: invalid index in a state of MT_64")
+	}
+	mt.InitByState(state, int(i))
+}
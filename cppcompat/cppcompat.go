@@ -0,0 +1,263 @@
+/*
+Package cppcompat runs the random number distributions of C++ standard libraries on Mersenne Twisters of mtrand.
+
+std::mt19937 and std::mt19937_64 generate the same sequences with mtrand.MT32 and mtrand.MT64,
+but the distributions of C++ standard library are implementation-defined,
+so the same generator gives different numbers with libstdc++ (GCC) and libc++ (LLVM).
+This package reproduces the algorithms of both libraries.
+
+	mt := mtrand.NewMT32()
+	mt.Init(1) // std::mt19937 g(1);
+	g := cppcompat.NewMT19937(cppcompat.LibStdCXX, mt)
+	n := g.UniformInt32(1, 6) // std::uniform_int_distribution<int>(1, 6)(g);
+
+The algorithms of libstdc++ are the ones of GCC 11 and later, which use Lemire's method for uniform integers.
+The tests of libstdc++ compare with outputs of GCC 12. The libc++ algorithms are ported from its source,
+but are not tested against outputs of libc++, which was not available; the tests check properties derived from the source.
+*/
+package cppcompat
+
+import (
+	"math"
+	"math/bits"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/internal/libm"
+)
+
+// C++ standard library implementation
+type Lib int
+
+const (
+	LibStdCXX Lib = iota // GNU libstdc++
+	LibCXX               // LLVM libc++
+)
+
+// Gen is a C++ uniform random bit generator with a standard library implementation
+type Gen struct {
+	lib  Lib
+	next func() uint64
+	bits uint // number of bits of a generated number; 32 or 64
+}
+
+// NewMT19937() uses MT32 as std::mt19937
+func NewMT19937(lib Lib, mt *mtrand.MT32) *Gen {
+	return &Gen{lib: lib, next: func() uint64 { return uint64(mt.GenUint32()) }, bits: 32}
+}
+
+// NewMT19937_64() uses MT64 as std::mt19937_64
+func NewMT19937_64(lib Lib, mt *mtrand.MT64) *Gen {
+	return &Gen{lib: lib, next: mt.GenUint64, bits: 64}
+}
+
+// Lib() returns the standard library implementation of the generator
+func (g *Gen) Lib() Lib {
+	return g.lib
+}
+
+// range of the generator, max() - min()
+func (g *Gen) urngRange() uint64 {
+	return ^uint64(0) >> (64 - g.bits)
+}
+
+//
+// uniform_int_distribution
+//
+
+// uniform integer on [0, urange]; digits is the number of bits of the result type
+func (g *Gen) uniformInt(urange uint64, digits uint) uint64 {
+	if g.lib == LibCXX {
+		return g.uniformIntCXX(urange, digits)
+	}
+	return g.uniformIntStdCXX(urange)
+}
+
+// libstdc++ uniform_int_distribution::operator()
+func (g *Gen) uniformIntStdCXX(urange uint64) uint64 {
+	urngrange := g.urngRange()
+	switch {
+	case urngrange > urange: // downscaling
+		uerange := urange + 1
+		if g.bits == 64 {
+			// 128-bit product
+			hi, lo := bits.Mul64(g.next(), uerange)
+			if lo < uerange {
+				threshold := -uerange % uerange
+				for lo < threshold {
+					hi, lo = bits.Mul64(g.next(), uerange)
+				}
+			}
+			return hi
+		}
+		// 64-bit product
+		r32 := uint32(uerange)
+		product := g.next() * uint64(r32)
+		if uint32(product) < r32 {
+			threshold := -r32 % r32
+			for uint32(product) < threshold {
+				product = g.next() * uint64(r32)
+			}
+		}
+		return product >> 32
+
+	case urngrange < urange: // upscaling
+		uerngrange := urngrange + 1
+		for {
+			tmp := uerngrange * g.uniformIntStdCXX(urange/uerngrange)
+			ret := tmp + g.next()
+			if ret <= urange && ret >= tmp {
+				return ret
+			}
+		}
+	}
+	return g.next()
+}
+
+// libc++ uniform_int_distribution::operator()
+func (g *Gen) uniformIntCXX(urange uint64, digits uint) uint64 {
+	rp := (urange + 1) & (^uint64(0) >> (64 - digits))
+	if rp == 1 {
+		return 0
+	}
+	if rp == 0 {
+		return g.independentBits(digits)
+	}
+	w := digits - uint(bits.LeadingZeros64(rp)-(64-int(digits))) - 1
+	if rp&(^uint64(0)>>(64-w)) != 0 {
+		w++
+	}
+	for {
+		u := g.independentBits(w)
+		if u < rp {
+			return u
+		}
+	}
+}
+
+// libc++ __independent_bits_engine; generates a w-bit number
+func (g *Gen) independentBits(w uint) uint64 {
+	if g.bits == 64 {
+		// the range of the engine is 2^64 and overflows to zero
+		return g.next() & (^uint64(0) >> (64 - w))
+	}
+	// the range of the engine is 2^32; no rejection happens
+	n := (w + 31) / 32
+	w0 := w / n
+	n0 := n - w%n
+	mask0 := ^uint64(0) >> (64 - w0)
+	mask1 := ^uint64(0) >> (64 - (w0 + 1))
+	var s uint64
+	for k := uint(0); k < n0; k++ {
+		s = s<<w0 + g.next()&mask0
+	}
+	for k := n0; k < n; k++ {
+		s = s<<(w0+1) + g.next()&mask1
+	}
+	return s
+}
+
+// UniformInt32() is std::uniform_int_distribution<int>(a, b)
+func (g *Gen) UniformInt32(a, b int32) int32 {
+	return a + int32(g.uniformInt(uint64(uint32(b)-uint32(a)), 32))
+}
+
+// UniformUint32() is std::uniform_int_distribution<unsigned int>(a, b)
+func (g *Gen) UniformUint32(a, b uint32) uint32 {
+	return a + uint32(g.uniformInt(uint64(b-a), 32))
+}
+
+// UniformInt64() is std::uniform_int_distribution<long long>(a, b)
+func (g *Gen) UniformInt64(a, b int64) int64 {
+	return a + int64(g.uniformInt(uint64(b)-uint64(a), 64))
+}
+
+// UniformUint64() is std::uniform_int_distribution<unsigned long long>(a, b)
+func (g *Gen) UniformUint64(a, b uint64) uint64 {
+	return a + g.uniformInt(b-a, 64)
+}
+
+//
+// generate_canonical and uniform_real_distribution
+//
+
+// GenerateCanonical() is std::generate_canonical<double, 53>()
+func (g *Gen) GenerateCanonical() float64 {
+	r := float64(g.urngRange()) + 1
+	m := (53 + int(g.bits) - 1) / int(g.bits)
+	sum, tmp := 0.0, 1.0
+	for k := 0; k < m; k++ {
+		sum += float64(float64(g.next()) * tmp)
+		tmp *= r
+	}
+	ret := sum / tmp
+	if ret >= 1 && g.lib == LibStdCXX {
+		ret = math.Nextafter(1, 0)
+	}
+	return ret
+}
+
+// GenerateCanonical32() is std::generate_canonical<float, 24>()
+func (g *Gen) GenerateCanonical32() float32 {
+	r := float32(g.urngRange()) + 1
+	sum := float32(g.next()) // one number is enough for 24 bits
+	ret := sum / r
+	if ret >= 1 && g.lib == LibStdCXX {
+		ret = math.Nextafter32(1, 0)
+	}
+	return ret
+}
+
+// UniformReal() is std::uniform_real_distribution<double>(a, b)
+func (g *Gen) UniformReal(a, b float64) float64 {
+	return float64(g.GenerateCanonical()*(b-a)) + a
+}
+
+//
+// normal_distribution
+//
+
+// NormalDistribution is std::normal_distribution<double>.
+// It keeps the second number of a pair generated by Marsaglia polar method, as the C++ object does.
+type NormalDistribution struct {
+	Mean, Stddev float64
+	saved        float64
+	available    bool
+}
+
+// NewNormalDistribution() creates a normal distribution, like std::normal_distribution<double>(mean, stddev)
+func NewNormalDistribution(mean, stddev float64) *NormalDistribution {
+	return &NormalDistribution{Mean: mean, Stddev: stddev}
+}
+
+// Reset() discards the saved number, like std::normal_distribution::reset()
+func (d *NormalDistribution) Reset() {
+	d.available = false
+}
+
+// Sample() generates a number with g
+func (d *NormalDistribution) Sample(g *Gen) float64 {
+	var ret float64
+	if d.available {
+		d.available = false
+		ret = d.saved
+	} else {
+		var x, y, r2 float64
+		for {
+			x = 2*g.GenerateCanonical() - 1
+			y = 2*g.GenerateCanonical() - 1
+			r2 = float64(x*x) + float64(y*y)
+			if r2 <= 1 && r2 != 0 {
+				break
+			}
+		}
+		mult := math.Sqrt(-2 * libm.Log(r2) / r2)
+		d.available = true
+		if g.lib == LibCXX {
+			// libc++ returns the first one and keeps the second
+			d.saved, ret = y*mult, x*mult
+		} else {
+			d.saved, ret = x*mult, y*mult
+		}
+	}
+	return float64(ret*d.Stddev) + d.Mean
+}
@@ -0,0 +1,159 @@
+package cppcompat_test
+
+import (
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/cppcompat"
+)
+
+func newMT19937(lib cppcompat.Lib, seed uint32) *cppcompat.Gen {
+	mt := mtrand.NewMT32()
+	mt.Init(seed)
+	return cppcompat.NewMT19937(lib, mt)
+}
+
+func newMT19937_64(lib cppcompat.Lib, seed uint64) *cppcompat.Gen {
+	mt := mtrand.NewMT64()
+	mt.Init(seed)
+	return cppcompat.NewMT19937_64(lib, mt)
+}
+
+// compare with outputs of libstdc++ of GCC 12
+func TestLibStdCXX(t *testing.T) {
+	lib := cppcompat.LibStdCXX
+
+	// uniform_int_distribution<int>(1, 6) with mt19937(1)
+	target1 := []int32{3, 6, 5, 6, 1, 1, 2, 6}
+	g := newMT19937(lib, 1)
+	for i, v := range target1 {
+		if r := g.UniformInt32(1, 6); r != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d", i, v, r)
+		}
+	}
+
+	// uniform_int_distribution<long long>(-10^12, 10^12) with mt19937(1); upscaling
+	target2 := []int64{-162493468437, 442819347528, -999449709687, -394413732219}
+	g = newMT19937(lib, 1)
+	for i, v := range target2 {
+		if r := g.UniformInt64(-1000000000000, 1000000000000); r != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d", i, v, r)
+		}
+	}
+
+	// uniform_int_distribution<uint64_t>(0, UINT64_MAX) with mt19937(1)
+	target3 := []uint64{7692698082559361259, 13287641507927168072, 2109959069025161}
+	g = newMT19937(lib, 1)
+	for i, v := range target3 {
+		if r := g.UniformUint64(0, ^uint64(0)); r != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d", i, v, r)
+		}
+	}
+
+	// uniform_int_distribution<int>(0, 99) with mt19937_64(1)
+	target4 := []int32{13, 13, 45, 2, 35, 91, 47, 7}
+	g = newMT19937_64(lib, 1)
+	for i, v := range target4 {
+		if r := g.UniformInt32(0, 99); r != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d", i, v, r)
+		}
+	}
+
+	// generate_canonical<double, 53>
+	target5 := []float64{0.99718480823026556, 0.93255736136816547, 0.128124447772306}
+	g = newMT19937(lib, 1)
+	for i, v := range target5 {
+		if r := g.GenerateCanonical(); r != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+	}
+	target6 := []float64{0.13387664401253274, 0.13640703636619725, 0.45121490384453816}
+	g = newMT19937_64(lib, 1)
+	for i, v := range target6 {
+		if r := g.GenerateCanonical(); r != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+	}
+
+	// generate_canonical<float, 24>
+	target7 := []float32{0.41702199, 0.997184813, 0.720324516}
+	g = newMT19937(lib, 1)
+	for i, v := range target7 {
+		if r := g.GenerateCanonical32(); r != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+	}
+
+	// uniform_real_distribution<double>(-2.5, 7.0)
+	target8 := []float64{6.9732556781875221, 6.3592949329975728, -1.282817746163093}
+	g = newMT19937(lib, 1)
+	for i, v := range target8 {
+		if r := g.UniformReal(-2.5, 7.0); r != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+	}
+
+	// normal_distribution<double>(1.0, 2.0)
+	target9 := []float64{-0.099492357910899276, -1.8057454182558419, 4.1655045839502804, -1.0902936208840446, 1.5151870825829801}
+	g = newMT19937(lib, 1)
+	d := cppcompat.NewNormalDistribution(1.0, 2.0)
+	for i, v := range target9 {
+		if r := d.Sample(g); r != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+	}
+	target10 := []float64{-0.38683176162103994, -0.039399956754155308, 0.68682363917932543, -0.24894784633514505, -0.79514624370949216}
+	g = newMT19937_64(lib, 1)
+	d = cppcompat.NewNormalDistribution(0, 1)
+	for i, v := range target10 {
+		if r := d.Sample(g); r != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+	}
+}
+
+// check libc++ algorithms against properties derived from its source
+func TestLibCXX(t *testing.T) {
+
+	// a power-of-2 range takes the lower bits of a single number
+	mt := mtrand.NewMT32()
+	mt.Init(1)
+	g := newMT19937(cppcompat.LibCXX, 1)
+	for i := 0; i < 100; i++ {
+		v, r := mt.GenUint32()&0xff, g.UniformUint32(0, 255)
+		if r != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d", i, v, r)
+		}
+	}
+
+	// a 64-bit number from two 32-bit numbers; the first one is the upper half
+	mt.Init(1)
+	g = newMT19937(cppcompat.LibCXX, 1)
+	for i := 0; i < 100; i++ {
+		hi, lo := uint64(mt.GenUint32()), uint64(mt.GenUint32())
+		v, r := hi<<32|lo, g.UniformUint64(0, ^uint64(0))
+		if r != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d", i, v, r)
+		}
+	}
+
+	// generate_canonical is the same with libstdc++ below 1.0
+	g1, g2 := newMT19937(cppcompat.LibCXX, 1), newMT19937(cppcompat.LibStdCXX, 1)
+	for i := 0; i < 100; i++ {
+		v, r := g2.GenerateCanonical(), g1.GenerateCanonical()
+		if r != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+	}
+
+	// normal_distribution gives the two numbers of a pair in the reverse order of libstdc++
+	g1, g2 = newMT19937(cppcompat.LibCXX, 1), newMT19937(cppcompat.LibStdCXX, 1)
+	d1, d2 := cppcompat.NewNormalDistribution(0, 1), cppcompat.NewNormalDistribution(0, 1)
+	for i := 0; i < 50; i++ {
+		a1, b1 := d1.Sample(g1), d1.Sample(g1)
+		a2, b2 := d2.Sample(g2), d2.Sample(g2)
+		if a1 != b2 || b1 != a2 {
+			t.Errorf("invalid pair for iteration %d: expected (%v, %v), actual (%v, %v)", i, b2, a2, a1, b1)
+		}
+	}
+}
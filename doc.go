@@ -8,7 +8,17 @@ The purpose of this package is to provide RNGs that generate exact same random n
 
 MT32 is 32-bit Mersenne Twister RNG, which generates same sequences with "mt19937ar.c" implementation.
 MT64 is 64-bit Mersenne Twister RNG, which generates same sequences with "mt19937-64.c" implementation as well.
+MTEngine is a Mersenne Twister RNG with arbitrary parameters, like C++ std::mersenne_twister_engine, with the presets MT11213A/B, MT19937 and MT19937-64.
+WELL is the WELL RNG family of Panneton, L'Ecuyer and Matsumoto (WELL512a, WELL1024a, WELL19937a/c and WELL44497a/b).
+XSadd is the XORSHIFT-ADD RNG of Saito and Matsumoto, which has the same methods with MT32.
+TinyMT32 is the 32-bit Tiny Mersenne Twister. SearchTinyMT32Params() searches its parameter sets like "tinymt32dc", but does not reproduce the published tables, and CheckTinyMT32Params() verifies a published row.
+SFMT is the SIMD-oriented Fast Mersenne Twister SFMT-19937, with jump-ahead of SFMT-jump.
+DSFMT is the double precision SIMD-oriented Fast Mersenne Twister dSFMT-19937, which generates doubles on [1, 2) natively.
 
-Additionally, both RNGs have interfaces for Go's built-in math/rand and cryto/rand.
+Additionally, the RNGs have interfaces for Go's built-in math/rand and cryto/rand, and are Sources of math/rand/v2 with go 1.22 and later.
+Seed(int64) of MT32 and MT64 follows a SeedPolicy given at construction, to reproduce the seeding of C++, Python or NumPy.
+MT32 and MT64 jump ahead by Jump(). LockedMT32 and LockedMT64 are safe for concurrent use, and MT32Pool and MT64Pool share generators on separate streams among goroutines without locks.
+ByteStream reads the bytes of a generator regardless of the sizes of reads.
+MT32 and MT64 save and restore their states, with their seed policies, by MarshalBinary() and UnmarshalBinary().
 */
 package mtrand
@@ -0,0 +1,204 @@
+/*
+	dsfmt.go
+	a translation of Double precision SIMD-oriented Fast Mersenne Twister (dSFMT) random number generator
+	by Mutsuo Saito and Makoto Matsumoto, "dSFMT.c" of dSFMT 2.2, with MEXP = 19937
+
+	See http://www.math.sci.hiroshima-u.ac.jp/m-mat/MT/SFMT/index.html
+	for original C source code and tech info.
+*/
+
+package mtrand
+
+import "math"
+
+const (
+	dsfmtMexp = 19937
+	dsfmtN    = (dsfmtMexp-128)/104 + 1 // number of 128-bit words in the state, without the lung
+	dsfmtN64  = dsfmtN * 2              // number of 64-bit words (doubles) in the state
+	dsfmtN32  = (dsfmtN + 1) * 4        // number of 32-bit words in the state, with the lung
+	dsfmtPos1 = 117
+	dsfmtSL1  = 19
+	dsfmtSR   = 12
+
+	dsfmtLowMask   = 0x000f_ffff_ffff_ffff
+	dsfmtHighConst = 0x3ff0_0000_0000_0000
+)
+
+var (
+	dsfmtMsk = [2]uint64{0x000f_faff_ffff_fb3f, 0x000f_fdff_fc90_fffd}
+	dsfmtFix = [2]uint64{0x9001_4964_b32f_4329, 0x3b8d_12ac_548a_7c7a}
+	dsfmtPcv = [2]uint64{0x3d84_e1ac_0dc8_2880, 0x0000_0000_0000_0001}
+)
+
+// Double precision SIMD-oriented Fast Mersenne Twister random generator, dSFMT-19937.
+// The native output is a double on [1, 2), whose 52-bit mantissa is random.
+type DSFMT struct {
+	state []uint64 // 128-bit word k is state[2k:2k+2]; word dsfmtN is the lung
+	i     int      // index of 64-bit words. if i==dsfmtN64+1, then state[] is not initialized
+}
+
+// NewDSFMT() creates a new dSFMT-19937 random generator
+func NewDSFMT() *DSFMT {
+	return &DSFMT{state: make([]uint64, 2*(dsfmtN+1)), i: dsfmtN64 + 1}
+}
+
+// the recursion formula; r may be the same with a
+func dsfmtDoRecursion(r, a, b, lung []uint64) {
+	t0, t1 := a[0], a[1]
+	l0, l1 := lung[0], lung[1]
+	lung[0] = (t0 << dsfmtSL1) ^ (l1 >> 32) ^ (l1 << 32) ^ b[0]
+	lung[1] = (t1 << dsfmtSL1) ^ (l0 >> 32) ^ (l0 << 32) ^ b[1]
+	r[0] = (lung[0] >> dsfmtSR) ^ (lung[0] & dsfmtMsk[0]) ^ t0
+	r[1] = (lung[1] >> dsfmtSR) ^ (lung[1] & dsfmtMsk[1]) ^ t1
+}
+
+// w128() returns 128-bit word k of the state
+func (d *DSFMT) w128(k int) []uint64 {
+	return d.state[2*k : 2*k+2]
+}
+
+// fill the state array with new values
+func (d *DSFMT) genRandAll() {
+	lung := d.w128(dsfmtN)
+	i := 0
+	for ; i < dsfmtN-dsfmtPos1; i++ {
+		dsfmtDoRecursion(d.w128(i), d.w128(i), d.w128(i+dsfmtPos1), lung)
+	}
+	for ; i < dsfmtN; i++ {
+		dsfmtDoRecursion(d.w128(i), d.w128(i), d.w128(i+dsfmtPos1-dsfmtN), lung)
+	}
+}
+
+// set the exponent bits so that every 64-bit word of the state is a double on [1, 2)
+func (d *DSFMT) initialMask() {
+	for i := 0; i < dsfmtN64; i++ {
+		d.state[i] = d.state[i]&dsfmtLowMask | dsfmtHighConst
+	}
+}
+
+// make sure that the period is 2^MEXP-1
+func (d *DSFMT) periodCertification() {
+	lung := d.w128(dsfmtN)
+	inner := (lung[0] ^ dsfmtFix[0]) & dsfmtPcv[0]
+	inner ^= (lung[1] ^ dsfmtFix[1]) & dsfmtPcv[1]
+	for i := 32; i > 0; i >>= 1 {
+		inner ^= inner >> i
+	}
+	if inner&1 == 1 {
+		return
+	}
+	lung[1] ^= 1 // the lowest bit of PCV2 is 1
+}
+
+// set the state from 32-bit words; 64-bit words are little endian
+func (d *DSFMT) setState32(st []uint32) {
+	for i := range d.state {
+		d.state[i] = uint64(st[2*i+1])<<32 | uint64(st[2*i])
+	}
+	d.initialMask()
+	d.periodCertification()
+	d.i = dsfmtN64
+}
+
+// init the state with a seed
+func (d *DSFMT) Init(seed uint32) {
+	st := make([]uint32, dsfmtN32)
+	st[0] = seed
+	for i := 1; i < dsfmtN32; i++ {
+		st[i] = 1812433253*(st[i-1]^(st[i-1]>>30)) + uint32(i)
+	}
+	d.setState32(st)
+}
+
+// init with an array
+func (d *DSFMT) InitByArray(key []uint32) {
+	const (
+		size = dsfmtN32
+		lag  = 11 // for size >= 623
+		mid  = (size - lag) / 2
+	)
+	st := make([]uint32, size)
+	for i := range st {
+		st[i] = 0x8b8b_8b8b
+	}
+
+	keylen := len(key)
+	count := size
+	if keylen+1 > size {
+		count = keylen + 1
+	}
+
+	r := sfmtFunc1(st[0] ^ st[mid] ^ st[size-1])
+	st[mid] += r
+	r += uint32(keylen)
+	st[mid+lag] += r
+	st[0] = r
+	count--
+
+	i, j := 1, 0
+	for ; j < count && j < keylen; j++ {
+		r = sfmtFunc1(st[i] ^ st[(i+mid)%size] ^ st[(i+size-1)%size])
+		st[(i+mid)%size] += r
+		r += key[j] + uint32(i)
+		st[(i+mid+lag)%size] += r
+		st[i] = r
+		i = (i + 1) % size
+	}
+	for ; j < count; j++ {
+		r = sfmtFunc1(st[i] ^ st[(i+mid)%size] ^ st[(i+size-1)%size])
+		st[(i+mid)%size] += r
+		r += uint32(i)
+		st[(i+mid+lag)%size] += r
+		st[i] = r
+		i = (i + 1) % size
+	}
+	for j = 0; j < size; j++ {
+		r = sfmtFunc2(st[i] + st[(i+mid)%size] + st[(i+size-1)%size])
+		st[(i+mid)%size] ^= r
+		r -= uint32(i)
+		st[(i+mid+lag)%size] ^= r
+		st[i] = r
+		i = (i + 1) % size
+	}
+
+	d.setState32(st)
+}
+
+// GenRaw() returns the bits of the next double on [1, 2); the lower 52 bits are random
+func (d *DSFMT) GenRaw() uint64 {
+	if d.i >= dsfmtN64 {
+		if d.i == dsfmtN64+1 { // if Init() has not been called,
+			d.Init(5489) // a default initial seed is used
+		}
+		d.genRandAll()
+		d.i = 0
+	}
+	r := d.state[d.i]
+	d.i++
+	return r
+}
+
+// generates a random number on [0,0xffffffff]-interval; the lower 32 bits of the mantissa
+func (d *DSFMT) GenUint32() uint32 {
+	return uint32(d.GenRaw())
+}
+
+// generates a random number on [1,2)-real-interval
+func (d *DSFMT) GenClose1Open2() float64 {
+	return math.Float64frombits(d.GenRaw())
+}
+
+// generates a random number on [0,1)-real-interval
+func (d *DSFMT) GenCloseOpen() float64 {
+	return d.GenClose1Open2() - 1.0
+}
+
+// generates a random number on (0,1]-real-interval
+func (d *DSFMT) GenOpenClose() float64 {
+	return 2.0 - d.GenClose1Open2()
+}
+
+// generates a random number on (0,1)-real-interval
+func (d *DSFMT) GenOpenOpen() float64 {
+	return math.Float64frombits(d.GenRaw()|1) - 1.0
+}
@@ -0,0 +1,56 @@
+package mtrand_test
+
+import (
+	"math"
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+)
+
+// compare generated numbers with the known outputs.
+// Julia 1.0 to 1.10 seeds its MersenneTwister, a dSFMT-19937, by init_by_array() with the 32-bit words of the seed.
+func TestDSFMT(t *testing.T) {
+	cases := []struct {
+		key []uint32
+		v   float64 // first number on [0,1)
+	}{
+		{[]uint32{0}, 0.8236475079774124},
+		{[]uint32{42}, 0.5331830160438613},
+		{[]uint32{1234}, 0.5908446386657102},
+	}
+	d := mtrand.NewDSFMT()
+	for i, c := range cases {
+		d.InitByArray(c.key)
+		if r := d.GenCloseOpen(); r != c.v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, c.v, r)
+		}
+	}
+}
+
+// every output is a double on [1,2), and the interval functions take the same numbers
+func TestDSFMTRange(t *testing.T) {
+	d1, d2 := mtrand.NewDSFMT(), mtrand.NewDSFMT()
+	d1.Init(1234)
+	d2.Init(1234)
+	for i := 0; i < 2000; i++ {
+		raw := d1.GenRaw()
+		if raw>>52 != 0x3ff {
+			t.Fatalf("invalid exponent for iteration %d: %#x", i, raw)
+		}
+		f := math.Float64frombits(raw)
+		var v, r float64
+		switch i % 4 {
+		case 0:
+			v, r = f, d2.GenClose1Open2()
+		case 1:
+			v, r = f-1.0, d2.GenCloseOpen()
+		case 2:
+			v, r = 2.0-f, d2.GenOpenClose()
+		case 3:
+			v, r = math.Float64frombits(raw|1)-1.0, d2.GenOpenOpen()
+		}
+		if r != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+//go:build go1.22
+// +build go1.22
+
+package mtrand_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/mixcode/golib-mtrand"
+)
+
+func Example_mathV2() {
+	// Example of feeding a Mersenne Twister to math/rand/v2
+	src := mtrand.NewMT64Source(5489)
+	rng := rand.New(src)
+	fmt.Println(rng.Uint64())
+
+	// save the state, and replay the numbers from it
+	saved, _ := src.(*mtrand.MT64).MarshalBinary()
+	a := rng.IntN(100)
+	mt := mtrand.NewMT64()
+	if err := mt.UnmarshalBinary(saved); err != nil {
+		panic(err)
+	}
+	b := rand.New(mt).IntN(100)
+	fmt.Println(a == b)
+
+	// Output:
+	// 14514284786278117030
+	// true
+}
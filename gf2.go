@@ -0,0 +1,192 @@
+/*
+	gf2.go
+	helpers for linear algebra over GF(2), used for jump and parameter search
+*/
+
+package mtrand
+
+import (
+	"math/bits"
+)
+
+// berlekampMassey() finds the shortest linear recurrence of a bit sequence.
+// It returns the connection polynomial c[0..l], c[0]==1, such that
+// s[k] = c[1]*s[k-1] + ... + c[l]*s[k-l] for all k >= l.
+// To find a recurrence of degree d, len(s) must be at least 2*d.
+func berlekampMassey(s []uint8) (c []uint8, l int) {
+	n := len(s)
+	c, b := make([]uint8, n+1), make([]uint8, n+1)
+	c[0], b[0] = 1, 1
+	m := 1
+	for k := 0; k < n; k++ {
+		d := s[k]
+		for i := 1; i <= l; i++ {
+			d ^= c[i] & s[k-i]
+		}
+		if d == 0 {
+			m++
+			continue
+		}
+		t := append([]uint8(nil), c...)
+		for i := 0; i+m <= n; i++ {
+			c[i+m] ^= b[i]
+		}
+		if 2*l <= k {
+			l, b, m = k+1-l, t, 1
+		} else {
+			m++
+		}
+	}
+	return c[:l+1], l
+}
+
+// a polynomial over GF(2) of arbitrary degree; bit i is the coefficient of x^i
+type f2poly []uint64
+
+func newF2poly(degree int) f2poly {
+	return make(f2poly, degree/64+1)
+}
+
+func (p f2poly) bit(i int) uint64 {
+	return p[i/64] >> (i % 64) & 1
+}
+
+func (p f2poly) setBit(i int) {
+	p[i/64] |= 1 << (i % 64)
+}
+
+// word() returns 64 bits of p starting at bit i; bits out of range are zero
+func (p f2poly) word(i int) uint64 {
+	q, r := i/64, uint(i%64)
+	var w uint64
+	if q < len(p) {
+		w = p[q] >> r
+	}
+	if r != 0 && q+1 < len(p) {
+		w |= p[q+1] << (64 - r)
+	}
+	return w
+}
+
+// xorShifted() does p ^= q * x^shift, ignoring the bits beyond the length of p
+func (p f2poly) xorShifted(q f2poly, shift int) {
+	ws, bs := shift/64, uint(shift%64)
+	end := ws + len(q) // the words beyond q*x^shift are not changed
+	if end > len(p)-1 {
+		end = len(p) - 1
+	}
+	for i := end; i >= ws; i-- {
+		j := i - ws
+		var w uint64
+		if j < len(q) {
+			w = q[j] << bs
+		}
+		if bs != 0 && j > 0 && j-1 < len(q) {
+			w |= q[j-1] >> (64 - bs)
+		}
+		p[i] ^= w
+	}
+}
+
+// berlekampMasseyPacked() is berlekampMassey() for a long sequence of n bits packed in s.
+// It returns the connection polynomial c and its degree l.
+func berlekampMasseyPacked(s f2poly, n int) (c f2poly, l int) {
+	// reversed sequence, so that s[k-l..k] is a contiguous bit range
+	rs := newF2poly(n)
+	for i := 0; i < n; i++ {
+		if s.bit(i) != 0 {
+			rs.setBit(n - 1 - i)
+		}
+	}
+	c, b := newF2poly(n), newF2poly(n)
+	c[0], b[0] = 1, 1
+	m := 1
+	for k := 0; k < n; k++ {
+		// d = sum of c[i]*s[k-i] for i = 0 to l; s[k-i] is rs[n-1-k+i]
+		var d uint64
+		off := n - 1 - k
+		for i := 0; i <= l; i += 64 {
+			d ^= c.word(i) & rs.word(off+i) // c has no terms beyond x^l
+		}
+		if bits.OnesCount64(d)&1 == 0 {
+			m++
+			continue
+		}
+		if 2*l <= k {
+			t := append(f2poly(nil), c...)
+			c.xorShifted(b, m)
+			l, b, m = k+1-l, t, 1
+		} else {
+			c.xorShifted(b, m)
+			m++
+		}
+	}
+	return c, l
+}
+
+// mulXMod() does p = p*x mod m, where m is of degree d and p is of degree < d
+func (p f2poly) mulXMod(m f2poly, d int) {
+	for i := len(p) - 1; i > 0; i-- {
+		p[i] = p[i]<<1 | p[i-1]>>63
+	}
+	p[0] <<= 1
+	if p.bit(d) != 0 {
+		for i := range p {
+			p[i] ^= m[i]
+		}
+	}
+}
+
+// f2modTable is a modulus with a table to reduce 8 bits at once
+type f2modTable struct {
+	m f2poly
+	d int
+	r [256]f2poly // r[v]: multiples of m that clear the top 8 bits v of a polynomial of degree d+7
+}
+
+// newF2modTable() makes the reduction table of m of degree d
+func newF2modTable(m f2poly, d int) *f2modTable {
+	t := &f2modTable{m: m, d: d}
+	for v := range t.r {
+		p := newF2poly(d + 8) // the bits being cleared
+		for j := 0; j < 8; j++ {
+			if v>>uint(j)&1 != 0 {
+				p.setBit(d + j)
+			}
+		}
+		acc := newF2poly(d + 8) // the sum of shifted m
+		for j := 7; j >= 0; j-- {
+			if p.bit(d+j) != 0 {
+				p.xorShifted(m, j)
+				acc.xorShifted(m, j)
+			}
+		}
+		t.r[v] = acc
+	}
+	return t
+}
+
+// squareMod() returns p*p mod t.m, where p is of degree < t.d
+func (t *f2modTable) squareMod(p f2poly) f2poly {
+	d := t.d
+	sq := newF2poly(2 * d)
+	for i := 0; i < d; i++ {
+		if p.bit(i) != 0 {
+			sq.setBit(2 * i)
+		}
+	}
+	i := 2*d - 2
+	for ; i-7 >= d; i -= 8 {
+		if v := sq.word(i-7) & 0xff; v != 0 {
+			sq.xorShifted(t.r[v], i-7-d)
+		}
+	}
+	for ; i >= d; i-- {
+		if sq.bit(i) != 0 {
+			sq.xorShifted(t.m, i-d)
+		}
+	}
+	r := newF2poly(d)
+	copy(r, sq)
+	return r
+}
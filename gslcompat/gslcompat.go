@@ -0,0 +1,149 @@
+/*
+Package gslcompat reproduces gsl_rng_mt19937 of the GNU Scientific Library on a Mersenne Twister of mtrand.
+
+gsl_rng_mt19937 is MT19937, the same generator with mtrand.MT32,
+except that the seed 0, the default seed of GSL, is replaced by 4357.
+RNG in this package generates the same numbers with GSL for the same seed.
+
+	r := gslcompat.New(mtrand.NewMT32())
+	r.Set(0)                       // gsl_rng_set(r, 0)
+	x := r.Uniform()               // gsl_rng_uniform(r)
+	n := r.UniformInt(6)           // gsl_rng_uniform_int(r, 6)
+	z := r.GaussianZiggurat(1.0)   // gsl_ran_gaussian_ziggurat(r, 1.0)
+
+Like math/rand, invalid arguments cause a panic, where GSL calls its error handler.
+
+The generator is tested with the check value of GSL's own test. Gaussian() and GaussianZiggurat() are tested
+by their distributions, not by values captured from GSL, which was not available, and the Ziggurat tables
+are regenerated rather than copied from gausszig.c; see zigtab.go.
+*/
+package gslcompat
+
+import (
+	"math"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/internal/libm"
+)
+
+const (
+	Min = 0           // gsl_rng_min() of gsl_rng_mt19937
+	Max = 0xffff_ffff // gsl_rng_max() of gsl_rng_mt19937
+)
+
+// RNG is a random number generator with the algorithms of gsl_rng_mt19937
+type RNG struct {
+	mt *mtrand.MT32
+}
+
+// New() creates an RNG on mt. The state of mt is used as is until Set() is called.
+func New(mt *mtrand.MT32) *RNG {
+	return &RNG{mt: mt}
+}
+
+// MT32() returns the underlying generator
+func (r *RNG) MT32() *mtrand.MT32 {
+	return r.mt
+}
+
+// Set() is gsl_rng_set(); the seed 0 is replaced by 4357, and only the lower 32 bits of seed are used
+func (r *RNG) Set(seed uint64) {
+	if seed == 0 {
+		seed = 4357 // the default seed is 4357
+	}
+	r.mt.Init(uint32(seed))
+}
+
+// Get() is gsl_rng_get(); a number on [Min, Max]
+func (r *RNG) Get() uint32 {
+	return r.mt.GenUint32()
+}
+
+// Uniform() is gsl_rng_uniform(); a number on [0, 1) with 32-bit resolution
+func (r *RNG) Uniform() float64 {
+	return float64(float64(r.mt.GenUint32()) / 4294967296.0)
+}
+
+// UniformPos() is gsl_rng_uniform_pos(); a number on (0, 1)
+func (r *RNG) UniformPos() float64 {
+	for {
+		if x := r.Uniform(); x != 0 {
+			return x
+		}
+	}
+}
+
+// UniformInt() is gsl_rng_uniform_int(); a number on [0, n) by scaling down with rejection
+func (r *RNG) UniformInt(n uint32) uint32 {
+	const rng = Max - Min
+	if n == 0 {
+		panic("gslcompat: invalid n, either 0 or exceeds maximum value of generator")
+	}
+	scale := rng / n
+	for {
+		if k := (r.mt.GenUint32() - Min) / scale; k < n {
+			return k
+		}
+	}
+}
+
+// Gaussian() is gsl_ran_gaussian(); a normal number with mean 0 by the polar Box-Muller method.
+// Unlike many others, the second number of a pair is not kept.
+func (r *RNG) Gaussian(sigma float64) float64 {
+	var x, y, r2 float64
+	for {
+		// choose x,y in uniform square (-1,-1) to (+1,+1)
+		x = -1 + float64(2*r.UniformPos())
+		y = -1 + float64(2*r.UniformPos())
+		// see if it is in the unit circle
+		r2 = float64(x*x) + float64(y*y)
+		if r2 <= 1.0 && r2 != 0 {
+			break
+		}
+	}
+	return sigma * y * math.Sqrt(-2.0*libm.Log(r2)/r2)
+}
+
+// Ugaussian() is gsl_ran_ugaussian(); Gaussian(1)
+func (r *RNG) Ugaussian() float64 {
+	return r.Gaussian(1.0)
+}
+
+// GaussianZiggurat() is gsl_ran_gaussian_ziggurat(); a normal number with mean 0
+// by the Ziggurat method of Marsaglia and Tsang, in the modification of Jochen Voss
+func (r *RNG) GaussianZiggurat(sigma float64) float64 {
+	var x, y, sign float64
+	for {
+		// a 32-bit number gives the step and the sign, and a sample from 2^24
+		k := r.mt.GenUint32() - Min
+		i := k & 0xff
+		j := (k >> 8) & 0xff_ffff
+
+		sign = -1
+		if i&0x80 != 0 {
+			sign = +1
+		}
+		i &= 0x7f
+
+		x = float64(j) * wtab[i]
+		if j < ktab[i] {
+			break
+		}
+
+		if i < 127 {
+			y0, y1 := ytab[i], ytab[i+1]
+			u1 := r.Uniform()
+			y = y1 + float64((y0-y1)*u1)
+		} else {
+			u1 := 1.0 - r.Uniform()
+			u2 := r.Uniform()
+			x = zigR - libm.Log(u1)/zigR
+			y = libm.Exp(-zigR*(x-zigR/2)) * u2
+		}
+
+		if y < libm.Exp(-0.5*x*x) {
+			break
+		}
+	}
+	return sign * sigma * x
+}
@@ -0,0 +1,84 @@
+package gslcompat_test
+
+import (
+	"math"
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/gslcompat"
+)
+
+func TestRNG(t *testing.T) {
+	r := gslcompat.New(mtrand.NewMT32())
+
+	// rng_test(gsl_rng_mt19937, 4357, 1000, 1186927261) of GSL's rng/test.c
+	r.Set(4357)
+	var v uint32
+	for i := 0; i < 1000; i++ {
+		v = r.Get()
+	}
+	if v != 1186927261 {
+		t.Errorf("invalid 1000th value: expected %v, actual %v", 1186927261, v)
+	}
+
+	// the seed 0 is 4357
+	r.Set(0)
+	mt := mtrand.NewMT32()
+	mt.Init(4357)
+	for i := 0; i < 10; i++ {
+		if x, v := r.Get(), mt.GenUint32(); x != v {
+			t.Errorf("invalid value for iteration %d: expected %x, actual %x", i, v, x)
+		}
+	}
+
+	// uniform is divided by 2^32, and uniform_int scales down by (2^32-1)/n
+	r.Set(1)
+	mt.Init(1)
+	for i := 0; i < 10; i++ {
+		if x, v := r.Uniform(), float64(mt.GenUint32())/4294967296.0; x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+	for i := 0; i < 10; i++ {
+		v := mt.GenUint32() / (0xffff_ffff / 3)
+		for v >= 3 {
+			v = mt.GenUint32() / (0xffff_ffff / 3)
+		}
+		if x := r.UniformInt(3); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+}
+
+// check the mean and the variance of the normal distributions
+func TestGaussian(t *testing.T) {
+	r := gslcompat.New(mtrand.NewMT32())
+	for _, gen := range []func(float64) float64{r.Gaussian, r.GaussianZiggurat} {
+		r.Set(0)
+		const n = 200000
+		sum, sum2, tail := 0.0, 0.0, 0
+		for i := 0; i < n; i++ {
+			x := gen(2.0)
+			sum += x
+			sum2 += x * x
+			if math.Abs(x) > 2*3.44428647676 {
+				tail++
+			}
+		}
+		mean, variance := sum/n, sum2/n-(sum/n)*(sum/n)
+		if math.Abs(mean) > 0.03 || math.Abs(variance-4) > 0.06 {
+			t.Errorf("invalid distribution: mean %v, variance %v", mean, variance)
+		}
+		if tail == 0 {
+			t.Errorf("no numbers from the tail")
+		}
+	}
+
+	// the polar method does not keep the second number
+	r.Set(3)
+	x := r.Ugaussian()
+	r.Set(3)
+	if y := r.Gaussian(1.0); x != y {
+		t.Errorf("invalid ugaussian: expected %v, actual %v", y, x)
+	}
+}
@@ -0,0 +1,27 @@
+package gslcompat
+
+import (
+	"math"
+	"testing"
+)
+
+// every strip of the Ziggurat has the same area
+func TestZigTab(t *testing.T) {
+	x := func(i int) float64 { return wtab[i] * (1 << 24) } // x[i+1]
+	area := x(0) * (ytab[0] - ytab[1])
+	for i := 1; i < 127; i++ {
+		if a := x(i) * (ytab[i] - ytab[i+1]); math.Abs(a-area) > 1e-9 {
+			t.Errorf("invalid area of strip %d: expected %v, actual %v", i, area, a)
+		}
+		if k := uint32(float64(1<<24) * x(i-1) / x(i)); k != ktab[i] {
+			t.Errorf("invalid ktab[%d]: expected %v, actual %v", i, k, ktab[i])
+		}
+	}
+	// the base strip; a box under ytab[127] and the tail
+	if a := x(127) * ytab[127]; math.Abs(a-area) > 1e-9 {
+		t.Errorf("invalid area of the base strip: expected %v, actual %v", area, a)
+	}
+	if math.Abs(x(126)-zigR) > 1e-10 {
+		t.Errorf("invalid right-most step: %v", x(126))
+	}
+}
@@ -7,10 +7,9 @@
 
 package mtrand
 
-// mt32.Seed() is an interface member for math/rand
+// mt32.Seed() is an interface member for math/rand; see SeedPolicy for the initialization
 func (mt *MT32) Seed(seed int64) {
-	key := []uint32{uint32(seed & 0xffff_ffff), uint32(seed >> 32)}
-	mt.InitByArray(key)
+	mt.seedByPolicy(seed)
 }
 
 // mt32.Int63() is an interface member for math/rand
@@ -46,9 +45,9 @@ func (mt *MT32) Read(buf []byte) (n int, err error) {
 	return
 }
 
-// mt64.Seed() is an interface member for math/rand
+// mt64.Seed() is an interface member for math/rand; see SeedPolicy for the initialization
 func (mt *MT64) Seed(seed int64) {
-	mt.Init(uint64(seed))
+	mt.seedByPolicy(seed)
 }
 
 // mt64.Int63() is an interface member for math/rand
@@ -82,3 +81,139 @@ func (mt *MT64) Read(buf []byte) (n int, err error) {
 	}
 	return
 }
+
+// read32() fills buf with little-endian 32-bit words from gen; the unused bytes of the last word are discarded
+func read32(gen func() uint32, buf []byte) (n int, err error) {
+	l := len(buf)
+	for l > 4 {
+		u32 := gen()
+		buf[0], buf[1], buf[2], buf[3] = byte(u32), byte(u32>>8), byte(u32>>16), byte(u32>>24)
+		buf = buf[4:]
+		l -= 4
+		n += 4
+	}
+	if l > 0 {
+		u32 := gen()
+		for i := 0; i < l; i++ {
+			buf[i] = byte(u32)
+			u32 >>= 8
+			n++
+		}
+	}
+	return
+}
+
+// WELL.Seed() is an interface member for math/rand
+func (w *WELL) Seed(seed int64) {
+	key := []uint32{uint32(seed & 0xffff_ffff), uint32(seed >> 32)}
+	w.InitByArray(key)
+}
+
+// WELL.Int63() is an interface member for math/rand
+func (w *WELL) Int63() int64 {
+	r1, r2 := int64(w.GenUint32()), int64(w.GenInt31())
+	return r2<<32 | r1
+}
+
+// WELL.Uint64() is an interface member for math/rand (added in go 1.8)
+func (w *WELL) Uint64() uint64 {
+	r1, r2 := uint64(w.GenUint32()), uint64(w.GenUint32())
+	return r2<<32 | r1
+}
+
+// WELL.Read() is an io.Reader interface for crypto/rand
+func (w *WELL) Read(buf []byte) (n int, err error) {
+	return read32(w.GenUint32, buf)
+}
+
+// XSadd.Seed() is an interface member for math/rand
+func (x *XSadd) Seed(seed int64) {
+	key := []uint32{uint32(seed & 0xffff_ffff), uint32(seed >> 32)}
+	x.InitByArray(key)
+}
+
+// XSadd.Int63() is an interface member for math/rand
+func (x *XSadd) Int63() int64 {
+	r1, r2 := int64(x.GenUint32()), int64(x.GenInt31())
+	return r2<<32 | r1
+}
+
+// XSadd.Uint64() is an interface member for math/rand (added in go 1.8)
+func (x *XSadd) Uint64() uint64 {
+	r1, r2 := uint64(x.GenUint32()), uint64(x.GenUint32())
+	return r2<<32 | r1
+}
+
+// XSadd.Read() is an io.Reader interface for crypto/rand
+func (x *XSadd) Read(buf []byte) (n int, err error) {
+	return read32(x.GenUint32, buf)
+}
+
+// TinyMT32.Seed() is an interface member for math/rand
+func (mt *TinyMT32) Seed(seed int64) {
+	key := []uint32{uint32(seed & 0xffff_ffff), uint32(seed >> 32)}
+	mt.InitByArray(key)
+}
+
+// TinyMT32.Int63() is an interface member for math/rand
+func (mt *TinyMT32) Int63() int64 {
+	r1, r2 := int64(mt.GenUint32()), int64(mt.GenInt31())
+	return r2<<32 | r1
+}
+
+// TinyMT32.Uint64() is an interface member for math/rand (added in go 1.8)
+func (mt *TinyMT32) Uint64() uint64 {
+	r1, r2 := uint64(mt.GenUint32()), uint64(mt.GenUint32())
+	return r2<<32 | r1
+}
+
+// TinyMT32.Read() is an io.Reader interface for crypto/rand
+func (mt *TinyMT32) Read(buf []byte) (n int, err error) {
+	return read32(mt.GenUint32, buf)
+}
+
+// SFMT.Seed() is an interface member for math/rand
+func (s *SFMT) Seed(seed int64) {
+	key := []uint32{uint32(seed & 0xffff_ffff), uint32(seed >> 32)}
+	s.InitByArray(key)
+}
+
+// SFMT.Int63() is an interface member for math/rand
+func (s *SFMT) Int63() int64 {
+	r1, r2 := int64(s.GenUint32()), int64(s.GenInt31())
+	return r2<<32 | r1
+}
+
+// SFMT.Uint64() is an interface member for math/rand (added in go 1.8)
+func (s *SFMT) Uint64() uint64 {
+	r1, r2 := uint64(s.GenUint32()), uint64(s.GenUint32())
+	return r2<<32 | r1
+}
+
+// SFMT.Read() is an io.Reader interface for crypto/rand
+func (s *SFMT) Read(buf []byte) (n int, err error) {
+	return read32(s.GenUint32, buf)
+}
+
+// DSFMT.Seed() is an interface member for math/rand
+func (d *DSFMT) Seed(seed int64) {
+	key := []uint32{uint32(seed & 0xffff_ffff), uint32(seed >> 32)}
+	d.InitByArray(key)
+}
+
+// DSFMT.Int63() is an interface member for math/rand
+func (d *DSFMT) Int63() int64 {
+	r1, r2 := int64(d.GenUint32()), int64(d.GenUint32()>>1)
+	return r2<<32 | r1
+}
+
+// DSFMT.Uint64() is an interface member for math/rand (added in go 1.8)
+func (d *DSFMT) Uint64() uint64 {
+	r1, r2 := uint64(d.GenUint32()), uint64(d.GenUint32())
+	return r2<<32 | r1
+}
+
+// DSFMT.Read() is an io.Reader interface for crypto/rand
+func (d *DSFMT) Read(buf []byte) (n int, err error) {
+	return read32(d.GenUint32, buf)
+}
@@ -0,0 +1,46 @@
+//go:build go1.22
+// +build go1.22
+
+/*
+	interface_v2.go
+	interfaces to math/rand/v2 of go 1.22 and later
+*/
+
+package mtrand
+
+import (
+	"encoding"
+	"math/rand/v2"
+)
+
+// the generators are rand.Source of math/rand/v2 by their Uint64() methods
+var (
+	_ rand.Source = (*MT32)(nil)
+	_ rand.Source = (*MT64)(nil)
+	_ rand.Source = (*WELL)(nil)
+	_ rand.Source = (*XSadd)(nil)
+	_ rand.Source = (*TinyMT32)(nil)
+	_ rand.Source = (*SFMT)(nil)
+	_ rand.Source = (*DSFMT)(nil)
+
+	_ encoding.BinaryMarshaler   = (*MT32)(nil)
+	_ encoding.BinaryUnmarshaler = (*MT32)(nil)
+	_ encoding.BinaryMarshaler   = (*MT64)(nil)
+	_ encoding.BinaryUnmarshaler = (*MT64)(nil)
+)
+
+// NewMT32Source() returns an MT32 initialized by Init(seed), as a rand.Source of math/rand/v2.
+// The state can be saved with MarshalBinary() of the returned MT32.
+func NewMT32Source(seed uint32) rand.Source {
+	mt := NewMT32()
+	mt.Init(seed)
+	return mt
+}
+
+// NewMT64Source() returns an MT64 initialized by Init(seed), as a rand.Source of math/rand/v2.
+// The state can be saved with MarshalBinary() of the returned MT64.
+func NewMT64Source(seed uint64) rand.Source {
+	mt := NewMT64()
+	mt.Init(seed)
+	return mt
+}
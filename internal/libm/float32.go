@@ -0,0 +1,219 @@
+/*
+	float32.go
+	logf(), sinf() and cosf() of glibc, which are the ones of ARM optimized-routines,
+	ported with their tables for the same bits; the generic variants, built without FMA
+*/
+
+package libm
+
+import (
+	"math"
+)
+
+// __logf_data; 1/c and log(c) of the 16 subintervals of [OFF, 2*OFF]
+var logfTab = [16]struct{ invc, logc float64 }{
+	{0x1.661ec79f8f3bep+0, -0x1.57bf7808caadep-2},
+	{0x1.571ed4aaf883dp+0, -0x1.2bef0a7c06ddbp-2},
+	{0x1.49539f0f010bp+0, -0x1.01eae7f513a67p-2},
+	{0x1.3c995b0b80385p+0, -0x1.b31d8a68224e9p-3},
+	{0x1.30d190c8864a5p+0, -0x1.6574f0ac07758p-3},
+	{0x1.25e227b0b8eap+0, -0x1.1aa2bc79c81p-3},
+	{0x1.1bb4a4a1a343fp+0, -0x1.a4e76ce8c0e5ep-4},
+	{0x1.12358f08ae5bap+0, -0x1.1973c5a611cccp-4},
+	{0x1.0953f419900a7p+0, -0x1.252f438e10c1ep-5},
+	{0x1p+0, 0x0p+0},
+	{0x1.e608cfd9a47acp-1, 0x1.aa5aa5df25984p-5},
+	{0x1.ca4b31f026aap-1, 0x1.c5e53aa362eb4p-4},
+	{0x1.b2036576afce6p-1, 0x1.526e57720db08p-3},
+	{0x1.9c2d163a1aa2dp-1, 0x1.bc2860d22477p-3},
+	{0x1.886e6037841edp-1, 0x1.1058bc8a07ee1p-2},
+	{0x1.767dcf5534862p-1, 0x1.4043057b6ee09p-2},
+}
+
+const (
+	logfLn2 = 0x1.62e42fefa39efp-1
+	logfA0  = -0x1.00ea348b88334p-2
+	logfA1  = 0x1.5575b0be00b6ap-2
+	logfA2  = -0x1.ffffef20a4123p-2
+	logfOff = 0x3f330000
+)
+
+// Logf() is logf() of glibc
+func Logf(x float32) float32 {
+	ix := math.Float32bits(x)
+	if ix == 0x3f800000 {
+		return 0
+	}
+	if ix-0x00800000 >= 0x7f800000-0x00800000 {
+		switch {
+		case ix*2 == 0:
+			return float32(math.Inf(-1))
+		case ix == 0x7f800000:
+			return x
+		case ix&0x80000000 != 0 || ix*2 >= 0xff000000:
+			return float32(math.NaN())
+		}
+		// subnormal; normalize
+		ix = math.Float32bits(x * 0x1p23)
+		ix -= 23 << 23
+	}
+
+	// x = 2^k z, z in [OFF, 2*OFF]
+	tmp := ix - logfOff
+	i := (tmp >> (23 - 4)) % 16
+	k := int32(tmp) >> 23
+	iz := ix - tmp&(0x1ff<<23)
+	invc, logc := logfTab[i].invc, logfTab[i].logc
+	z := float64(math.Float32frombits(iz))
+
+	// log(x) = log1p(z/c-1) + log(c) + k*Ln2; the products are rounded, as in the build without FMA
+	r := float64(z*invc) - 1
+	y0 := logc + float64(float64(k)*logfLn2)
+	r2 := float64(r * r)
+	y := float64(logfA1*r) + logfA2
+	y = float64(logfA0*r2) + y
+	y = float64(y*r2) + (y0 + r)
+	return float32(y)
+}
+
+// __sincosf_table; the second one is for the quadrants 2 and 3
+type sincosf struct {
+	sign               [4]float64
+	hpiInv, hpi        float64
+	c0, c1, c2, c3, c4 float64
+	s1, s2, s3         float64
+}
+
+var sincosfTable = [2]sincosf{
+	{
+		[4]float64{1, -1, -1, 1},
+		0x1.45f306dc9c883p+23, 0x1.921fb54442d18p+0,
+		0x1p+0, -0x1.ffffffd0c621cp-2, 0x1.55553e1068f19p-5, -0x1.6c087e89a359dp-10, 0x1.99343027bf8c3p-16,
+		-0x1.555545995a603p-3, 0x1.1107605230bc4p-7, -0x1.994eb3774cf24p-13,
+	},
+	{
+		[4]float64{1, -1, -1, 1},
+		0x1.45f306dc9c883p+23, 0x1.921fb54442d18p+0,
+		-0x1p+0, 0x1.ffffffd0c621cp-2, -0x1.55553e1068f19p-5, 0x1.6c087e89a359dp-10, -0x1.99343027bf8c3p-16,
+		-0x1.555545995a603p-3, 0x1.1107605230bc4p-7, -0x1.994eb3774cf24p-13,
+	},
+}
+
+// __inv_pio4; 4/pi in 192 bits, read by 8-bit offsets
+var invPio4 = [24]uint32{
+	0xa2, 0xa2f9, 0xa2f983, 0xa2f9836e,
+	0xf9836e4e, 0x836e4e44, 0x6e4e4415, 0x4e441529,
+	0x441529fc, 0x1529fc27, 0x29fc2757, 0xfc2757d1,
+	0x2757d1f5, 0x57d1f534, 0xd1f534dd, 0xf534ddc0,
+	0x34ddc0db, 0xddc0db62, 0xc0db6295, 0xdb629599,
+	0x6295993c, 0x95993c43, 0x993c4390, 0x3c439041,
+}
+
+const (
+	pi63 = 0x1.921fb54442d18p-62
+	pio4 = float32(0x1.921fb6p-1)
+)
+
+// top 12 bits of a float without the sign
+func abstop12(x float32) uint32 {
+	return (math.Float32bits(x) >> 20) & 0x7ff
+}
+
+// sinf_poly(); sin(x) for an even n, cos(x) for an odd n
+func sinfPoly(x, x2 float64, p *sincosf, n int) float32 {
+	if n&1 == 0 {
+		x3 := float64(x * x2)
+		s1 := p.s2 + float64(x2*p.s3)
+		x7 := float64(x3 * x2)
+		s := x + float64(x3*p.s1)
+		return float32(s + float64(x7*s1))
+	}
+	x4 := float64(x2 * x2)
+	c2 := p.c3 + float64(x2*p.c4)
+	c1 := p.c0 + float64(x2*p.c1)
+	x6 := float64(x4 * x2)
+	c := c1 + float64(x4*p.c2)
+	return float32(c + float64(x6*c2))
+}
+
+// reduce_fast(); x - n*pi/2, for |x| < 120
+func reduceFast(x float64, p *sincosf) (float64, int) {
+	r := float64(x * p.hpiInv)
+	n := int((int32(r) + 0x800000) >> 24)
+	return x - float64(float64(n)*p.hpi), n
+}
+
+// reduce_large(); x - n*pi/2 by the bits of 4/pi, for a finite |x| >= 120
+func reduceLarge(xi uint32) (float64, int) {
+	arr := invPio4[(xi>>26)&15:]
+	shift := (xi >> 23) & 7
+	xi = (xi&0xffffff | 0x800000) << shift
+	res0 := uint64(xi * arr[0])
+	res1 := uint64(xi) * uint64(arr[4])
+	res2 := uint64(xi) * uint64(arr[8])
+	res0 = res2>>32 | res0<<32
+	res0 += res1
+	n := (res0 + 1<<61) >> 62
+	res0 -= n << 62
+	return float64(int64(res0)) * pi63, int(n)
+}
+
+// Sinf() is sinf() of glibc
+func Sinf(y float32) float32 {
+	x := float64(y)
+	p := &sincosfTable[0]
+	switch {
+	case abstop12(y) < abstop12(pio4):
+		if abstop12(y) < abstop12(0x1p-12) {
+			return y
+		}
+		return sinfPoly(x, float64(x*x), p, 0)
+	case abstop12(y) < abstop12(120):
+		x, n := reduceFast(x, p)
+		s := p.sign[n&3]
+		if n&2 != 0 {
+			p = &sincosfTable[1]
+		}
+		return sinfPoly(float64(x*s), float64(x*x), p, n)
+	case abstop12(y) < abstop12(float32(math.Inf(1))):
+		xi := math.Float32bits(y)
+		sign := int(xi >> 31)
+		x, n := reduceLarge(xi)
+		s := p.sign[(n+sign)&3]
+		if (n+sign)&2 != 0 {
+			p = &sincosfTable[1]
+		}
+		return sinfPoly(float64(x*s), float64(x*x), p, n)
+	}
+	return float32(math.NaN())
+}
+
+// Cosf() is cosf() of glibc
+func Cosf(y float32) float32 {
+	x := float64(y)
+	p := &sincosfTable[0]
+	switch {
+	case abstop12(y) < abstop12(pio4):
+		if abstop12(y) < abstop12(0x1p-12) {
+			return 1
+		}
+		return sinfPoly(x, float64(x*x), p, 1)
+	case abstop12(y) < abstop12(120):
+		x, n := reduceFast(x, p)
+		s := p.sign[n&3]
+		if n&2 != 0 {
+			p = &sincosfTable[1]
+		}
+		return sinfPoly(float64(x*s), float64(x*x), p, n^1)
+	case abstop12(y) < abstop12(float32(math.Inf(1))):
+		xi := math.Float32bits(y)
+		sign := int(xi >> 31)
+		x, n := reduceLarge(xi)
+		s := p.sign[(n+sign)&3]
+		if (n+sign)&2 != 0 {
+			p = &sincosfTable[1]
+		}
+		return sinfPoly(float64(x*s), float64(x*x), p, n^1)
+	}
+	return float32(math.NaN())
+}
@@ -0,0 +1,259 @@
+/*
+Package libm provides math functions that give the same results with the C math library.
+
+Go's math functions are accurate within 1 ulp, but they are not always the nearest double,
+while glibc gives the nearest double for almost all arguments.
+Compatibility layers need the exact same bits with C, so the functions here are computed
+in double-double arithmetic (about 106 bits) and rounded once to the nearest double.
+They still differ from glibc in rare cases where glibc itself is not correctly rounded;
+about 1 in 1000 random arguments, while Go's math functions differ in 3 to 50 in 100.
+
+The float functions Logf(), Sinf() and Cosf() are not correctly rounded in glibc,
+so they are ports of the glibc functions with their tables, and give the same bits for all floats.
+*/
+package libm
+
+import (
+	"math"
+)
+
+// double-double number; the value is hi+lo, |lo| <= ulp(hi)/2
+type dd struct {
+	hi, lo float64
+}
+
+func twoSum(a, b float64) dd {
+	s := a + b
+	bb := s - a
+	e := (a - (s - bb)) + (b - bb)
+	return dd{s, e}
+}
+
+func quickTwoSum(a, b float64) dd {
+	s := a + b
+	return dd{s, b - (s - a)}
+}
+
+func twoProd(a, b float64) dd {
+	p := float64(a * b) // rounded, so that it is not fused into a following addition
+	return dd{p, math.FMA(a, b, -p)}
+}
+
+func (a dd) add(b dd) dd {
+	s := twoSum(a.hi, b.hi)
+	t := twoSum(a.lo, b.lo)
+	s.lo += t.hi
+	s = quickTwoSum(s.hi, s.lo)
+	s.lo += t.lo
+	return quickTwoSum(s.hi, s.lo)
+}
+
+func (a dd) mul(b dd) dd {
+	p := twoProd(a.hi, b.hi)
+	p.lo += float64(a.hi*b.lo) + float64(a.lo*b.hi)
+	return quickTwoSum(p.hi, p.lo)
+}
+
+func (a dd) mulF(b float64) dd {
+	p := twoProd(a.hi, b)
+	p.lo += float64(a.lo * b)
+	return quickTwoSum(p.hi, p.lo)
+}
+
+func (a dd) div(b dd) dd {
+	q1 := a.hi / b.hi
+	r := a.add(b.mulF(-q1))
+	q2 := r.hi / b.hi
+	r = r.add(b.mulF(-q2))
+	q3 := r.hi / b.hi
+	q := quickTwoSum(q1, q2)
+	return q.add(dd{q3, 0})
+}
+
+var (
+	ln2 = dd{0.6931471805599453, 2.3190468138462996e-17}
+)
+
+// logDD() computes log(x) in double-double, for finite x > 0
+func logDD(x float64) dd {
+	m, e := math.Frexp(x) // x = m * 2^e, 0.5 <= m < 1
+	if m < math.Sqrt2/2 {
+		m *= 2
+		e--
+	}
+	// log(m) = 2 * atanh(s), s = (m-1)/(m+1)
+	s := dd{m - 1, 0}.div(twoSum(m, 1))
+	s2 := s.mul(s)
+	sum := dd{0, 0}
+	term := s
+	for k := 1; k < 80; k += 2 {
+		t := term.div(dd{float64(k), 0})
+		sum = sum.add(t)
+		if math.Abs(t.hi) < 1e-40 {
+			break
+		}
+		term = term.mul(s2)
+	}
+	return sum.mulF(2).add(ln2.mulF(float64(e)))
+}
+
+// Log() returns the natural logarithm of x, rounded to the nearest double
+func Log(x float64) float64 {
+	switch {
+	case math.IsNaN(x) || x < 0:
+		return math.NaN()
+	case x == 0:
+		return math.Inf(-1)
+	case math.IsInf(x, 1):
+		return x
+	case x == 1:
+		return 0
+	}
+	r := logDD(x)
+	return r.hi + r.lo
+}
+
+// expDD() computes exp(x) in double-double, as a mantissa and a power of 2
+func expDD(x dd) (dd, int) {
+	k := math.Floor(x.hi/ln2.hi + 0.5)
+	r := x.add(ln2.mulF(-k))
+	sum := dd{1, 0}
+	term := dd{1, 0}
+	for n := 1; n < 40; n++ {
+		term = term.mul(r).div(dd{float64(n), 0})
+		sum = sum.add(term)
+		if math.Abs(term.hi) < 1e-40 {
+			break
+		}
+	}
+	return sum, int(k)
+}
+
+// Exp() returns e**x, rounded to the nearest double
+func Exp(x float64) float64 {
+	switch {
+	case math.IsNaN(x) || math.IsInf(x, 1):
+		return x
+	case math.IsInf(x, -1):
+		return 0
+	case x > 709.8:
+		return math.Inf(1)
+	case x < -745.2:
+		return 0
+	case x == 0:
+		return 1
+	}
+	m, k := expDD(dd{x, 0})
+	return math.Ldexp(m.hi+m.lo, k)
+}
+
+// Pow() returns x**y, rounded to the nearest double
+func Pow(x, y float64) float64 {
+	if x <= 0 || y == 0 || x == 1 || math.IsInf(x, 0) || math.IsInf(y, 0) || math.IsNaN(x) || math.IsNaN(y) {
+		return math.Pow(x, y)
+	}
+	l := logDD(x)
+	t := l.mulF(y)
+	switch {
+	case t.hi > 709.8:
+		return math.Inf(1)
+	case t.hi < -745.2:
+		return 0
+	}
+	m, k := expDD(t)
+	return math.Ldexp(m.hi+m.lo, k)
+}
+
+var (
+	// pi/2 in three parts
+	pio2 = [3]float64{1.5707963267948966, 6.123233995736766e-17, -1.4973849048591698e-33}
+)
+
+// sinCosDD() computes sin(r) and cos(r) in double-double, for |r| <= pi/4
+func sinCosDD(r dd) (s, c dd) {
+	r2 := r.mul(r)
+	s, c = r, dd{1, 0}
+	ts, tc := r, dd{1, 0}
+	for n := 2; n < 60; n += 2 {
+		ts = ts.mul(r2).div(dd{float64(-n * (n + 1)), 0})
+		tc = tc.mul(r2).div(dd{float64(-(n - 1) * n), 0})
+		s, c = s.add(ts), c.add(tc)
+		if math.Abs(tc.hi) < 1e-40 {
+			break
+		}
+	}
+	return
+}
+
+// reduce x by pi/2; x = k*(pi/2) + r
+func reducePio2(x float64) (r dd, k int) {
+	fk := math.Floor(x/pio2[0] + 0.5)
+	r = twoProd(fk, -pio2[0]).add(dd{x, 0})
+	r = r.add(twoProd(fk, -pio2[1]))
+	r = r.add(twoProd(fk, -pio2[2]))
+	return r, int(int64(fk) & 3)
+}
+
+func sinCos(x float64) (sin, cos float64) {
+	r, k := reducePio2(x)
+	s, c := sinCosDD(r)
+	switch k {
+	case 0:
+		return s.hi + s.lo, c.hi + c.lo
+	case 1:
+		return c.hi + c.lo, -(s.hi + s.lo)
+	case 2:
+		return -(s.hi + s.lo), -(c.hi + c.lo)
+	}
+	return -(c.hi + c.lo), s.hi + s.lo
+}
+
+// Sin() returns sin(x), rounded to the nearest double; accurate for |x| < 2^20
+func Sin(x float64) float64 {
+	if math.IsNaN(x) || math.IsInf(x, 0) || math.Abs(x) >= 1<<20 {
+		return math.Sin(x)
+	}
+	if x == 0 {
+		return x
+	}
+	s, _ := sinCos(x)
+	return s
+}
+
+// Cos() returns cos(x), rounded to the nearest double; accurate for |x| < 2^20
+func Cos(x float64) float64 {
+	if math.IsNaN(x) || math.IsInf(x, 0) || math.Abs(x) >= 1<<20 {
+		return math.Cos(x)
+	}
+	_, c := sinCos(x)
+	return c
+}
+
+// Acos() returns arccos(x), rounded to the nearest double
+func Acos(x float64) float64 {
+	switch {
+	case math.IsNaN(x) || x > 1 || x < -1:
+		return math.NaN()
+	case x == 1:
+		return 0
+	case x == -1:
+		return math.Pi
+	}
+	// refine math.Acos() by Newton's method on cos(y) - x
+	y := dd{math.Acos(x), 0}
+	for i := 0; i < 2; i++ {
+		r, k := reducePio2(y.hi)
+		r = r.add(dd{y.lo, 0})
+		s, c := sinCosDD(r)
+		switch k { // sin(y) and cos(y)
+		case 1:
+			s, c = c, dd{-s.hi, -s.lo}
+		case 2:
+			s, c = dd{-s.hi, -s.lo}, dd{-c.hi, -c.lo}
+		case 3:
+			s, c = dd{-c.hi, -c.lo}, s
+		}
+		y = y.add(c.add(dd{-x, 0}).div(s))
+	}
+	return y.hi + y.lo
+}
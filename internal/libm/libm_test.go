@@ -0,0 +1,95 @@
+package libm
+
+import (
+	"math"
+	"testing"
+)
+
+// compare with glibc; Go's math.Log gives different results for these arguments
+func TestLog(t *testing.T) {
+	target := []struct{ x, v float64 }{
+		{0.9405090880450124, -0.061333967294636395},
+		{0.30091186058528707, -1.2009378790897778},
+		{0.380657189299686, -0.9658560745051156},
+		{0.2065826619136986, -1.5770546468554432},
+		{0.865335013001561, -0.14463854876317192},
+		{1, 0},
+		{2, 0.6931471805599453},
+	}
+	for _, c := range target {
+		if r := Log(c.x); r != c.v {
+			t.Errorf("invalid value for Log(%v): expected %v, actual %v", c.x, c.v, r)
+		}
+	}
+}
+
+// compare with glibc; Go's math functions give different results for these arguments
+func TestFunctions(t *testing.T) {
+	target := []struct {
+		f    string
+		x, y float64
+		v    float64
+	}{
+		{"sin", 3.5538024366911394, 0, -0.40063499119873175},
+		{"sin", 4.5380722811031164, 0, -0.9848452772268389},
+		{"cos", 5.0184597870911505, 0, 0.3013143885657014},
+		{"cos", 0.9812011076641809, 0, 0.5560246284523637},
+		{"acos", -0.06312604647473075, 0, 1.6339643737687914},
+		{"acos", -0.5634360214392666, 0, 2.169335285119107},
+		{"exp", 31.70357818404945, 0, 5.870688833767354e+13},
+		{"exp", 5.175703034059486, 0, 176.92095201958347},
+		{"pow", 1.4983429685192575, 4.485328259490499, 6.133015059116248},
+		{"pow", 2.0828118680215857, 7.76172633047834, 297.3544443880255},
+	}
+	for _, c := range target {
+		var r float64
+		switch c.f {
+		case "sin":
+			r = Sin(c.x)
+		case "cos":
+			r = Cos(c.x)
+		case "acos":
+			r = Acos(c.x)
+		case "exp":
+			r = Exp(c.x)
+		case "pow":
+			r = Pow(c.x, c.y)
+		}
+		if r != c.v {
+			t.Errorf("invalid value for %s(%v, %v): expected %v, actual %v", c.f, c.x, c.y, c.v, r)
+		}
+	}
+}
+
+// compare with logf(), sinf() and cosf() of glibc without FMA; the functions are the same with glibc for all floats
+func TestFloat32(t *testing.T) {
+	target := []struct{ x, log, sin, cos float32 }{
+		{0x1.333334p-2, -0x1.34379p+0, 0x1.2e9cdap-2, 0x1.e921dep-1},
+		{0x1p+0, 0x0p+0, 0x1.aed548p-1, 0x1.14a28p-1},
+		{0x1.4p+1, 0x1.d5241p-1, 0x1.326afp-1, -0x1.9a2f7ep-1},
+		{0x1.16c2p-133, -0x1.7069e4p+6, 0x1.16c2p-133, 0x1p+0},
+		{0x1.fffffep-1, -0x1p-24, 0x1.aed548p-1, 0x1.14a282p-1},
+		{0x1.edd2f2p+6, 0x1.343774p+2, -0x1.9b9dc8p-1, -0x1.307e36p-1},
+		{0x1.921fb6p+1, 0x1.250d06p+0, -0x1.777a5cp-24, -0x1p+0},
+		{0x1p-1, -0x1.62e43p-1, 0x1.eaee88p-2, 0x1.c1528p-1},
+		{0x1.df999ap+6, 0x1.32589cp+2, 0x1.fc57fep-2, 0x1.bc749ap-1},
+		{0x1.e848p+19, 0x1.ba18aap+3, -0x1.6664b2p-2, 0x1.df9dfap-1},
+		{0x1.2eec2ep+101, 0x1.18b464p+6, -0x1.ecd5b8p-1, -0x1.158b9p-2},
+		{0x1.4f8b58p-17, -0x1.7069e2p+3, 0x1.4f8b58p-17, 0x1p+0},
+		{-0x1.0cccccp+1, float32(math.NaN()), -0x1.b9f696p-1, -0x1.027b2ep-1},
+		{-0x1.b48eb6p+42, float32(math.NaN()), 0x1.e76d32p-2, 0x1.c2467ap-1},
+		{0, float32(math.Inf(-1)), 0, 1},
+	}
+	same := func(a, b float32) bool { return a == b || a != a && b != b }
+	for _, c := range target {
+		if r := Logf(c.x); !same(r, c.log) {
+			t.Errorf("invalid value for Logf(%v): expected %v, actual %v", c.x, c.log, r)
+		}
+		if r := Sinf(c.x); !same(r, c.sin) {
+			t.Errorf("invalid value for Sinf(%v): expected %v, actual %v", c.x, c.sin, r)
+		}
+		if r := Cosf(c.x); !same(r, c.cos) {
+			t.Errorf("invalid value for Cosf(%v): expected %v, actual %v", c.x, c.cos, r)
+		}
+	}
+}
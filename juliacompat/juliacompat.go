@@ -0,0 +1,183 @@
+/*
+Package juliacompat reproduces Julia's Random.MersenneTwister on a dSFMT generator of mtrand.
+
+Julia's MersenneTwister is dSFMT-19937, the same generator with mtrand.DSFMT,
+and MersenneTwister in this package generates the same numbers with Julia for the same seed.
+
+	r := juliacompat.New(mtrand.NewDSFMT())
+	r.Seed(1234)           // r = MersenneTwister(1234)
+	x := r.Rand()          // rand(r)
+	n := r.RandRange(1, 6) // rand(r, 1:6)
+	z := r.Randn()         // randn(r)
+
+Julia takes the doubles from a cache filled by dsfmt_fill_array_close1_open2(),
+which holds the same numbers that successive calls of the generator return.
+64-bit integers, and ranges wider than 2^52, come from another cache of Julia, and are not provided;
+the order in which Julia fills and pops that cache could not be checked against Julia,
+so rand(r, UInt64) and rand(r, Int64) are left out rather than guessed.
+
+The numbers of SeedLegacy() and Rand() are tested with values from Julia.
+Seed() and Randn() are not: Julia was not available to capture values of the hashed seeds of Julia 1.11
+or of randn(), so Randn() is tested by its distribution only, and its Ziggurat tables are
+regenerated from their definition rather than copied from Julia; see zigtab.go.
+
+Like math/rand, invalid arguments cause a panic, where Julia throws ArgumentError or DomainError.
+*/
+package juliacompat
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"math/bits"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/internal/libm"
+)
+
+// MersenneTwister is a random number generator with the algorithms of Julia's Random.MersenneTwister
+type MersenneTwister struct {
+	d *mtrand.DSFMT
+}
+
+// New() creates a MersenneTwister on d. The state of d is used as is until one of seeding functions is called.
+func New(d *mtrand.DSFMT) *MersenneTwister {
+	return &MersenneTwister{d: d}
+}
+
+// DSFMT() returns the underlying generator
+func (r *MersenneTwister) DSFMT() *mtrand.DSFMT {
+	return r.d
+}
+
+//
+// seeding
+//
+
+// 32-bit words of the absolute value of n, from the least significant one; one word at least
+func seedWords(n *big.Int) []uint32 {
+	x := new(big.Int).Abs(n)
+	key := []uint32{uint32(x.Uint64())}
+	for x.Rsh(x, 32); x.Sign() > 0; x.Rsh(x, 32) {
+		key = append(key, uint32(x.Uint64()))
+	}
+	return key
+}
+
+// SeedBigInt() is Random.seed!(r, seed) for an integer, of Julia 1.11 and later; the seed is hashed by SHA-256
+func (r *MersenneTwister) SeedBigInt(seed *big.Int) {
+	neg := seed.Sign() < 0
+	n := seed
+	if neg {
+		n = new(big.Int).Not(seed) // ~seed
+	}
+	words := seedWords(n)
+	b := make([]byte, 0, 4*len(words)+1)
+	for _, w := range words {
+		b = append(b, byte(w), byte(w>>8), byte(w>>16), byte(w>>24))
+	}
+	if neg {
+		b = append(b, 0x01) // negative numbers have different hashes from positive ones
+	}
+	sum := sha256.Sum256(b)
+	key := make([]uint32, len(sum)/4)
+	for i := range key {
+		key[i] = uint32(sum[4*i]) | uint32(sum[4*i+1])<<8 | uint32(sum[4*i+2])<<16 | uint32(sum[4*i+3])<<24
+	}
+	r.d.InitByArray(key)
+}
+
+// Seed() is Random.seed!(r, seed) or MersenneTwister(seed) for an integer, of Julia 1.11 and later
+func (r *MersenneTwister) Seed(seed int64) {
+	r.SeedBigInt(big.NewInt(seed))
+}
+
+// SeedLegacy() is Random.seed!(r, seed) or MersenneTwister(seed) of Julia 1.0 to 1.10;
+// the 32-bit words of the seed are used as the key of init_by_array() as they are
+func (r *MersenneTwister) SeedLegacy(seed uint64) {
+	r.d.InitByArray(seedWords(new(big.Int).SetUint64(seed)))
+}
+
+//
+// integers
+//
+
+// raw bits of a double on [1, 2); rand(r, UInt52Raw())
+func (r *MersenneTwister) raw() uint64 {
+	return r.d.GenRaw()
+}
+
+// RandUint32() is rand(r, UInt32); the lower 32 bits of a double
+func (r *MersenneTwister) RandUint32() uint32 {
+	return uint32(r.raw())
+}
+
+// RandRange() is rand(r, a:b); an integer on [a, b] by the masked rejection of SamplerRangeFast
+func (r *MersenneTwister) RandRange(a, b int64) int64 {
+	if b < a {
+		panic("juliacompat: collection must be non-empty")
+	}
+	m := uint64(b) - uint64(a)
+	bw := 64 - bits.LeadingZeros64(m)
+	if bw > 52 {
+		panic("juliacompat: range wider than 2^52 is not supported")
+	}
+	mask := uint64(1)<<bw - 1
+	for {
+		if x := r.raw() & mask; x <= m {
+			return int64(uint64(a) + x)
+		}
+	}
+}
+
+//
+// floats
+//
+
+// Rand() is rand(r); a float on [0, 1) with 52-bit resolution
+func (r *MersenneTwister) Rand() float64 {
+	return r.d.GenCloseOpen()
+}
+
+// Randn() is randn(r), by the Ziggurat method of 256 levels.
+// Julia's log() and exp() of the rare cases are taken to be correctly rounded, as the ones of internal/libm.
+func (r *MersenneTwister) Randn() float64 {
+	for {
+		u := r.raw() & 0x000f_ffff_ffff_ffff // rand(r, UInt52())
+		rabs := int64(u >> 1)                // the lowest bit is the sign
+		idx := rabs & 0xff
+		x := float64(rabs) * wi[idx]
+		if u&1 != 0 {
+			x = float64(-rabs) * wi[idx]
+		}
+		if rabs < int64(ki[idx]) {
+			return x // 99.3% of the time
+		}
+
+		if idx == 0 {
+			// the tail
+			for {
+				xx := float64(-zigInvR * libm.Log(r.Rand()))
+				yy := -libm.Log(r.Rand())
+				if yy+yy > xx*xx {
+					if (rabs>>8)&1 != 0 {
+						return -zigR - xx
+					}
+					return zigR + xx
+				}
+			}
+		}
+		if float64((fi[idx-1]-fi[idx])*r.Rand())+fi[idx] < libm.Exp(-0.5*x*x) {
+			return x
+		}
+		// retry from the start, as randn(rng) in randn_unlikely()
+	}
+}
+
+// RandnN() is randn(r, n)
+func (r *MersenneTwister) RandnN(n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = r.Randn()
+	}
+	return out
+}
@@ -0,0 +1,88 @@
+package juliacompat_test
+
+import (
+	"math"
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/juliacompat"
+)
+
+// first numbers of rand(MersenneTwister(seed)) of Julia 1.0 to 1.10
+func TestRandLegacy(t *testing.T) {
+	cases := []struct {
+		seed uint64
+		v    float64
+	}{
+		{0, 0.8236475079774124}, {42, 0.5331830160438613}, {1234, 0.5908446386657102},
+	}
+	r := juliacompat.New(mtrand.NewDSFMT())
+	for i, c := range cases {
+		r.SeedLegacy(c.seed)
+		if x := r.Rand(); x != c.v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, c.v, x)
+		}
+	}
+}
+
+// integers and doubles come from the same doubles of the generator
+func TestSamplers(t *testing.T) {
+	r1, r2 := juliacompat.New(mtrand.NewDSFMT()), juliacompat.New(mtrand.NewDSFMT())
+	r1.Seed(1)
+	r2.Seed(1)
+	for i := 0; i < 1000; i++ {
+		f := r1.Rand() + 1.0
+		u := r2.RandUint32()
+		if v := uint32(math.Float64bits(f)); u != v {
+			t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v, u)
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		f := r1.Rand() + 1.0
+		m := math.Float64bits(f) & 0xf // 4 bits for 1:10
+		for m > 9 {
+			m = math.Float64bits(r1.Rand()+1.0) & 0xf
+		}
+		if v, n := int64(m)+1, r2.RandRange(1, 10); n != v {
+			t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v, n)
+		}
+	}
+}
+
+// seeds of different signs and magnitudes give different generators
+func TestSeed(t *testing.T) {
+	seen := make(map[float64]int64)
+	r := juliacompat.New(mtrand.NewDSFMT())
+	for _, s := range []int64{0, 1, -1, -2, 1 << 32, -1 << 32, math.MaxInt64, math.MinInt64} {
+		r.Seed(s)
+		x := r.Rand()
+		if p, ok := seen[x]; ok {
+			t.Errorf("seeds %d and %d give the same number %v", p, s, x)
+		}
+		seen[x] = s
+	}
+}
+
+// sample mean and variance of randn()
+func TestRandn(t *testing.T) {
+	r := juliacompat.New(mtrand.NewDSFMT())
+	r.Seed(12345)
+	const n = 1000000
+	sum, sum2, tail := 0.0, 0.0, 0
+	for _, x := range r.RandnN(n) {
+		sum += x
+		sum2 += x * x
+		if math.Abs(x) > 3.6541528853610088 {
+			tail++
+		}
+	}
+	mean := sum / n
+	variance := sum2/n - mean*mean
+	if math.Abs(mean) > 0.005 || math.Abs(variance-1) > 0.01 {
+		t.Errorf("invalid distribution: mean %v, variance %v", mean, variance)
+	}
+	// P(|x| > r) = 2.58e-4
+	if tail < 200 || tail > 320 {
+		t.Errorf("invalid number of tail values: %d", tail)
+	}
+}
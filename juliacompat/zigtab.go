@@ -0,0 +1,221 @@
+/*
+	zigtab.go
+	tables of the Ziggurat method of randn() of Julia's Random module
+
+	The tables have 256 levels with the right-most step at zigR, and an integer of 51 bits is scaled by wi.
+	The values are rounded from the exact ones, computed with the area of each strip
+	v = r*exp(-r^2/2) + integral of exp(-x^2/2) on [r, inf), the definition of the tables of Julia.
+	They are regenerated from the definition, and were not diffed with the tables in Julia's source,
+	so an entry may differ from Julia's in the last bit where the rounding of the two computations differs.
+*/
+
+package juliacompat
+
+const (
+	zigR    = 3.6541528853610088 // position of the right-most step
+	zigInvR = 1 / zigR
+)
+
+// thresholds of the integer part for the quick acceptance
+var ki = [256]uint64{
+	0x0007799ec012f7b2, 0x0000000000000000, 0x0006045f4c7de363, 0x0006d1aa7d5ec0a5,
+	0x000728fb3f60f777, 0x0007592af4e9fbc0, 0x000777a5c0bf655d, 0x00078ca3857d2256,
+	0x00079bf6b0ffe58b, 0x0007a7a34ab092ad, 0x0007b0d2f20dd1cb, 0x0007b83d3aa9cb52,
+	0x0007be597614224d, 0x0007c3788631abe9, 0x0007c7d32bc192ee, 0x0007cb9263a6e86d,
+	0x0007ced483edfa84, 0x0007d1b07ac0fd39, 0x0007d437ef2da5fc, 0x0007d678b069aa6e,
+	0x0007d87db38c5c87, 0x0007da4fc6a9ba62, 0x0007dbf611b37f3b, 0x0007dd7674d0f286,
+	0x0007ded5ce8205f6, 0x0007e018307fb62b, 0x0007e141081bd124, 0x0007e2533d712de8,
+	0x0007e3514bbd7718, 0x0007e43d54944b52, 0x0007e5192f25ef42, 0x0007e5e67481118d,
+	0x0007e6a6897c1ce2, 0x0007e75aa6c7f64c, 0x0007e803df8ee498, 0x0007e8a326eb6272,
+	0x0007e93954717a28, 0x0007e9c727f8648f, 0x0007ea4d4cc85a3c, 0x0007eacc5c4907a9,
+	0x0007eb44e0474cf6, 0x0007ebb754e47419, 0x0007ec242a3d8474, 0x0007ec8bc5d69645,
+	0x0007ecee83d3d6e9, 0x0007ed4cb8082f45, 0x0007eda6aee0170f, 0x0007edfcae2dfe68,
+	0x0007ee4ef5dccd3e, 0x0007ee9dc08c394e, 0x0007eee9441a17c7, 0x0007ef31b21b4fb1,
+	0x0007ef773846a8a7, 0x0007efba00d35a17, 0x0007effa32ccf69f, 0x0007f037f25e1278,
+	0x0007f0736112d12c, 0x0007f0ac9e145c25, 0x0007f0e3c65e1fcc, 0x0007f118f4ed8e54,
+	0x0007f14c42ed0dc8, 0x0007f17dc7daa0c3, 0x0007f1ad99aac6a5, 0x0007f1dbcce80015,
+	0x0007f20874cf56bf, 0x0007f233a36a3b9a, 0x0007f25d69a604ad, 0x0007f285d7694a92,
+	0x0007f2acfba75e3b, 0x0007f2d2e4720909, 0x0007f2f79f09c344, 0x0007f31b37ec883b,
+	0x0007f33dbae36abc, 0x0007f35f330f08d5, 0x0007f37faaf2fa79, 0x0007f39f2c805380,
+	0x0007f3bdc11f4f1c, 0x0007f3db71b83850, 0x0007f3f846bba121, 0x0007f4144829f846,
+	0x0007f42f7d9a8b9d, 0x0007f449ee420432, 0x0007f463a0f8675e, 0x0007f47c9c3ea77b,
+	0x0007f494e643cd8e, 0x0007f4ac84e9c475, 0x0007f4c37dc9cd50, 0x0007f4d9d638a432,
+	0x0007f4ef934a5b6a, 0x0007f504b9d5f33d, 0x0007f5194e78b352, 0x0007f52d55994a96,
+	0x0007f540d36aba0c, 0x0007f553cbef0e77, 0x0007f56642f9ec8f, 0x0007f5783c32f31e,
+	0x0007f589bb17f609, 0x0007f59ac2ff1525, 0x0007f5ab5718b15a, 0x0007f5bb7a71427c,
+	0x0007f5cb2ff31009, 0x0007f5da7a67cebe, 0x0007f5e95c7a24e7, 0x0007f5f7d8b7171e,
+	0x0007f605f18f5ef4, 0x0007f613a958ad0a, 0x0007f621024ed7e9, 0x0007f62dfe94f8cb,
+	0x0007f63aa036777a, 0x0007f646e928065a, 0x0007f652db488f88, 0x0007f65e786213ff,
+	0x0007f669c22a7d8a, 0x0007f674ba446459, 0x0007f67f623fc8db, 0x0007f689bb9ac294,
+	0x0007f693c7c22481, 0x0007f69d881217a6, 0x0007f6a6fdd6ac36, 0x0007f6b02a4c61ee,
+	0x0007f6b90ea0a7f4, 0x0007f6c1abf254c0, 0x0007f6ca03521664, 0x0007f6d215c2db82,
+	0x0007f6d9e43a3559, 0x0007f6e16fa0b329, 0x0007f6e8b8d23729, 0x0007f6efc09e4569,
+	0x0007f6f687c84cbf, 0x0007f6fd0f07ea09, 0x0007f703570925e2, 0x0007f709606cad03,
+	0x0007f70f2bc8036f, 0x0007f714b9a5b292, 0x0007f71a0a85725d, 0x0007f71f1edc4d9e,
+	0x0007f723f714c179, 0x0007f728938ed843, 0x0007f72cf4a03fa0, 0x0007f7311a945a16,
+	0x0007f73505ac4bf8, 0x0007f738b61f03bd, 0x0007f73c2c193dc0, 0x0007f73f67bd835c,
+	0x0007f74269242559, 0x0007f745305b31a1, 0x0007f747bd666428, 0x0007f74a103f12ed,
+	0x0007f74c28d414f5, 0x0007f74e0709a42d, 0x0007f74faab939f9, 0x0007f75113b16657,
+	0x0007f75241b5a155, 0x0007f753347e16b8, 0x0007f753ebb76b7c, 0x0007f75467027d05,
+	0x0007f754a5f4199d, 0x0007f754a814b207, 0x0007f7546ce003ae, 0x0007f753f3c4bb29,
+	0x0007f7533c240e92, 0x0007f75245514f41, 0x0007f7510e91726c, 0x0007f74f971a9012,
+	0x0007f74dde135797, 0x0007f74be2927971, 0x0007f749a39e051c, 0x0007f747202aba8a,
+	0x0007f744571b4e3c, 0x0007f741473f9efe, 0x0007f73def53dc43, 0x0007f73a4dff9bff,
+	0x0007f73661d4deaf, 0x0007f732294f003f, 0x0007f72da2d19444, 0x0007f728cca72bda,
+	0x0007f723a5000367, 0x0007f71e29f09627, 0x0007f7185970156b, 0x0007f7123156c102,
+	0x0007f70baf5c1e2c, 0x0007f704d1150a23, 0x0007f6fd93f1a4e5, 0x0007f6f5f53b10b6,
+	0x0007f6edf211023e, 0x0007f6e587671ce9, 0x0007f6dcb2021679, 0x0007f6d36e749c64,
+	0x0007f6c9b91bf4c6, 0x0007f6bf8e1c541b, 0x0007f6b4e95ce015, 0x0007f6a9c68356ff,
+	0x0007f69e20ef5211, 0x0007f691f3b517eb, 0x0007f6853997f321, 0x0007f677ed03ff19,
+	0x0007f66a08075bdc, 0x0007f65b844ab75a, 0x0007f64c5b091860, 0x0007f63c8506d4bc,
+	0x0007f62bfa8798fe, 0x0007f61ab34364b0, 0x0007f608a65a599a, 0x0007f5f5ca4737e8,
+	0x0007f5e214d05b48, 0x0007f5cd7af7066e, 0x0007f5b7f0e4c2a1, 0x0007f5a169d68fcf,
+	0x0007f589d80596a5, 0x0007f5712c8d0174, 0x0007f557574c912b, 0x0007f53c46c77193,
+	0x0007f51fe7feb9f2, 0x0007f5022646ecfb, 0x0007f4e2eb17ab1d, 0x0007f4c21dd4a3d1,
+	0x0007f49fa38ea394, 0x0007f47b5ebb62eb, 0x0007f4552ee27473, 0x0007f42cf03d58f5,
+	0x0007f4027b48549f, 0x0007f3d5a44119df, 0x0007f3a63a8fb552, 0x0007f37408155100,
+	0x0007f33ed05b55ec, 0x0007f3064f9c183e, 0x0007f2ca399c7ba1, 0x0007f28a384bb940,
+	0x0007f245ea1b7a2b, 0x0007f1fcdffe8f1b, 0x0007f1ae9af758cd, 0x0007f15a8917f27e,
+	0x0007f10001ccaaab, 0x0007f09e413c418a, 0x0007f034627733d7, 0x0007efc15815b8d5,
+	0x0007ef43e2bf7f55, 0x0007eeba84e31dfe, 0x0007ee237294df89, 0x0007ed7c7c170141,
+	0x0007ecc2f0d95d3a, 0x0007ebf377a46782, 0x0007eb09d6deb285, 0x0007ea00a4f17808,
+	0x0007e8d0d3da63d6, 0x0007e771023b0fcf, 0x0007e5d46c2f08d8, 0x0007e3e937669691,
+	0x0007e195978f1176, 0x0007deb2c0e05c1c, 0x0007db0362002a19, 0x0007d6202c151439,
+	0x0007cf4b8f00a2cb, 0x0007c4fd24520efd, 0x0007b362fbf81816, 0x00078d2d25998e24,
+}
+
+// widths of the levels divided by 2^51
+var wi = [256]float64{
+	1.736725412160263e-15, 9.5586603514556327e-17, 1.2708704834810623e-16, 1.4909740962495471e-16,
+	1.6658733631586268e-16, 1.8136120810119029e-16, 1.9429720153135588e-16, 2.0589500628482093e-16,
+	2.1646860576895422e-16, 2.2622940392218116e-16, 2.3532718914045892e-16, 2.4387234557428771e-16,
+	2.5194879829274225e-16, 2.5962199772528103e-16, 2.669440747364828e-16, 2.7395729685142446e-16,
+	2.8069646002484804e-16, 2.871905890411393e-16, 2.9346417484728883e-16, 2.9953809336782113e-16,
+	3.054303000719244e-16, 3.1115636338921572e-16, 3.1672988018581815e-16, 3.22162803505499e-16,
+	3.2746570407939751e-16, 3.326479811684171e-16, 3.3771803417353227e-16, 3.4268340353119356e-16,
+	3.4755088731729758e-16, 3.5232663846002031e-16, 3.5701624633953494e-16, 3.6162480571598339e-16,
+	3.661569752965354e-16, 3.7061702777236077e-16, 3.7500889278747798e-16, 3.7933619401549554e-16,
+	3.8360228129677279e-16, 3.8781025861250247e-16, 3.9196300853257678e-16, 3.9606321366256378e-16,
+	4.001133755254669e-16, 4.0411583124143332e-16, 4.0807276830960448e-16, 4.1198623774807442e-16,
+	4.1585816580828064e-16, 4.1969036444740733e-16, 4.2348454071520708e-16, 4.2724230518899761e-16,
+	4.3096517957162941e-16, 4.346546035512876e-16, 4.3831194100854571e-16, 4.4193848564470665e-16,
+	4.4553546609579137e-16, 4.491040505882875e-16, 4.5264535118571397e-16, 4.5616042766900381e-16,
+	4.5965029108849407e-16, 4.6311590702081647e-16, 4.6655819856008752e-16, 4.699780490694195e-16,
+	4.7337630471583237e-16, 4.7675377680908526e-16, 4.8011124396270155e-16, 4.834494540935008e-16,
+	4.8676912627422087e-16, 4.9007095245229938e-16, 4.9335559904654139e-16, 4.9662370843221783e-16,
+	4.9987590032409088e-16, 5.0311277306593187e-16, 5.0633490483427195e-16, 5.0954285476338923e-16,
+	5.1273716399787966e-16, 5.1591835667857364e-16, 5.1908694086703434e-16, 5.2224340941340417e-16,
+	5.2538824077194543e-16, 5.285218997682382e-16, 5.3164483832166176e-16, 5.3475749612647295e-16,
+	5.3786030129452348e-16, 5.4095367096239933e-16, 5.4403801186554671e-16, 5.4711372088173611e-16,
+	5.5018118554603362e-16, 5.5324078453927836e-16, 5.5629288815190902e-16, 5.5933785872484621e-16,
+	5.6237605106900435e-16, 5.6540781286489604e-16, 5.6843348504368141e-16, 5.714534021509204e-16,
+	5.7446789269419609e-16, 5.7747727947569648e-16, 5.8048187991076857e-16, 5.8348200633338921e-16,
+	5.8647796628943653e-16, 5.8947006281858718e-16, 5.9245859472561339e-16, 5.9544385684180598e-16,
+	5.9842614027720281e-16, 6.014057326642664e-16, 6.043829183936125e-16, 6.0735797884236057e-16,
+	6.1033119259564394e-16, 6.133028356617911e-16, 6.1627318168165963e-16, 6.192425021325847e-16,
+	6.2221106652737879e-16, 6.2517914260879998e-16, 6.2814699653988953e-16, 6.3111489309056042e-16,
+	6.34083095820806e-16, 6.3705186726088149e-16, 6.4002146908880247e-16, 6.4299216230548961e-16,
+	6.4596420740788321e-16, 6.4893786456033965e-16, 6.5191339376461587e-16, 6.5489105502874154e-16,
+	6.5787110853507413e-16, 6.6085381480782587e-16, 6.6383943488035057e-16, 6.6682823046247459e-16,
+	6.6982046410815579e-16, 6.7281639938375311e-16, 6.7581630103719006e-16, 6.7882043516829803e-16,
+	6.818290694006254e-16, 6.8484247305500383e-16, 6.8786091732516637e-16, 6.908846754557169e-16,
+	6.939140229227569e-16, 6.9694923761748294e-16, 6.999906000330764e-16, 7.0303839345521508e-16,
+	7.0609290415654822e-16, 7.0915442159548734e-16, 7.1222323861967788e-16, 7.152996516745303e-16,
+	7.1838396101720629e-16, 7.2147647093647067e-16, 7.245774899788387e-16, 7.2768733118146927e-16,
+	7.3080631231227429e-16, 7.3393475611774048e-16, 7.370729905789831e-16, 7.4022134917657997e-16,
+	7.4338017116476479e-16, 7.465498018555889e-16, 7.4973059291369793e-16, 7.5292290266240584e-16,
+	7.5612709640179217e-16, 7.5934354673958895e-16, 7.6257263393567558e-16, 7.6581474626104873e-16,
+	7.6907028037219191e-16, 7.7233964170182985e-16, 7.7562324486711744e-16, 7.7892151409638524e-16,
+	7.8223488367564108e-16, 7.8556379841610841e-16, 7.8890871414417552e-16, 7.9227009821522709e-16,
+	7.9564843005293662e-16, 7.99044201715713e-16, 8.0245791849212591e-16, 8.0589009952726568e-16,
+	8.0934127848215009e-16, 8.1281200422845008e-16, 8.1630284158098775e-16, 8.1981437207065329e-16,
+	8.2334719476060504e-16, 8.26901927108847e-16, 8.3047920588053737e-16, 8.3407968811366288e-16,
+	8.3770405214202216e-16, 8.4135299867980282e-16, 8.4502725197240968e-16, 8.4872756101861549e-16,
+	8.5245470086955962e-16, 8.5620947401062333e-16, 8.5999271183276646e-16, 8.6380527620052589e-16,
+	8.6764806112455816e-16, 8.715219945473698e-16, 8.7542804025171749e-16, 8.7936719990210427e-16,
+	8.833405152308408e-16, 8.8734907038131345e-16, 8.9139399442240861e-16, 8.9547646404950677e-16,
+	8.9959770648910994e-16, 9.0375900262601175e-16, 9.079616903740068e-16, 9.1220716831348461e-16,
+	9.1649689962191353e-16, 9.2083241632623076e-16, 9.2521532390956933e-16, 9.2964730630864167e-16,
+	9.3413013134252651e-16, 9.3866565661866598e-16, 9.4325583596767065e-16, 9.4790272646517382e-16,
+	9.5260849610662787e-16, 9.5737543220974496e-16, 9.6220595062948384e-16, 9.6710260588230542e-16,
+	9.7206810229016259e-16, 9.7710530627072088e-16, 9.8221725991905411e-16, 9.8740719604806711e-16,
+	9.9267855488079745e-16, 9.9803500261836449e-16, 1.0034804521436181e-15, 1.0090190861637457e-15,
+	1.0146553831467086e-15, 1.0203941464683124e-15, 1.0262405372613567e-15, 1.0322001115486456e-15,
+	1.0382788623515399e-15, 1.0444832676000471e-15, 1.0508203448355195e-15, 1.057297713900989e-15,
+	1.0639236690676801e-15, 1.0707072623632994e-15, 1.0776584002668106e-15, 1.0847879564403425e-15,
+	1.0921079038149563e-15, 1.0996314701785628e-15, 1.1073733224935752e-15, 1.1153497865853155e-15,
+	1.1235791107110833e-15, 1.1320817840164846e-15, 1.140880924258278e-15, 1.1500027537839792e-15,
+	1.1594771891449189e-15, 1.169338578691096e-15, 1.1796266352955801e-15, 1.190387629928289e-15,
+	1.2016759392543819e-15, 1.2135560818666897e-15, 1.2261054417450561e-15, 1.2394179789163251e-15,
+	1.2536093926602567e-15, 1.268824481425501e-15, 1.2852479319096109e-15, 1.3031206634689985e-15,
+	1.3227655770195326e-15, 1.3446300925011171e-15, 1.3693606835128518e-15, 1.397943667277524e-15,
+	1.4319989869661328e-15, 1.4744848603597596e-15, 1.5317872741611144e-15, 1.6227698675312968e-15,
+}
+
+// values of exp(-x^2/2) at the levels
+var fi = [256]float64{
+	1, 0.97710170126767082, 0.95987909180010611, 0.94519895344229909,
+	0.93206007595922991, 0.91999150503934646, 0.90872644005213032, 0.89809592189834297,
+	0.88798466075583282, 0.87830965580891684, 0.86900868803685649, 0.86003362119633109,
+	0.85134625845867751, 0.84291565311220373, 0.83471629298688299, 0.82672683394622093,
+	0.81892919160370192, 0.81130787431265572, 0.80384948317096383, 0.79654233042295841,
+	0.78937614356602404, 0.78234183265480195, 0.77543130498118662, 0.76863731579848571,
+	0.76195334683679483, 0.75537350650709567, 0.74889244721915638, 0.74250529634015061,
+	0.7362075981268621, 0.72999526456147568, 0.72386453346862967, 0.71781193263072152,
+	0.71183424887824798, 0.70592850133275376, 0.70009191813651117, 0.69432191612611627,
+	0.68861608300467136, 0.6829721616449943, 0.67738803621877308, 0.67186171989708166,
+	0.66639134390874977, 0.66097514777666277, 0.65561147057969693, 0.65029874311081637,
+	0.64503548082082196, 0.63982027745305614, 0.63465179928762327, 0.62952877992483625,
+	0.62445001554702617, 0.61941436060583399, 0.61442072388891344, 0.6094680649257731,
+	0.60455539069746733, 0.59968175261912482, 0.59484624376798689, 0.59004799633282545,
+	0.5852861792633709, 0.58055999610079034, 0.57586868297235316, 0.57121150673525267,
+	0.56658776325616389, 0.5619967758145239, 0.5574378936187655, 0.55291049042583185,
+	0.54841396325526537, 0.54394773119002582, 0.53951123425695158, 0.53510393238045717,
+	0.5307253044036615, 0.52637484717168403, 0.5220520746723214, 0.51775651722975591,
+	0.51348772074732651, 0.50924524599574761, 0.5050286679434679, 0.50083757512614835,
+	0.49667156905248933, 0.49253026364386815, 0.48841328470545758, 0.48432026942668288,
+	0.48025086590904642, 0.47620473271950547, 0.47218153846772976, 0.46818096140569321,
+	0.46420268904817397, 0.46024641781284248, 0.4563118526787161, 0.45239870686184824,
+	0.44850670150720273, 0.44463556539573917, 0.44078503466580377, 0.43695485254798533,
+	0.43314476911265209, 0.42935454102944126, 0.4255839313380218, 0.42183270922949573,
+	0.418100649837848, 0.41438753404089096, 0.41069314827018799, 0.40701728432947315,
+	0.40335973922111429, 0.399720314980197, 0.39609881851583223, 0.3924950614593154,
+	0.38890886001878855, 0.38534003484007706, 0.38178841087339344, 0.37825381724561896,
+	0.37473608713789086, 0.37123505766823922, 0.36775056977903225, 0.36428246812900372,
+	0.36083060098964775, 0.35739482014578022, 0.35397498080007656, 0.35057094148140588,
+	0.34718256395679348, 0.34380971314685055, 0.34045225704452164, 0.33711006663700588,
+	0.33378301583071823, 0.33047098137916342, 0.32717384281360129, 0.32389148237639104,
+	0.3206237849569053, 0.3173706380299135, 0.31413193159633707, 0.31090755812628634,
+	0.30769741250429189, 0.30450139197664983, 0.30131939610080288, 0.29815132669668531,
+	0.29499708779996164, 0.29185658561709499, 0.2887297284821827, 0.28561642681550159,
+	0.28251659308370741, 0.27943014176163772, 0.2763569892956681, 0.27329705406857691,
+	0.27025025636587519, 0.26721651834356114, 0.2641957639972608, 0.26118791913272088,
+	0.2581929113376189, 0.25521066995466168, 0.2522411260559419, 0.24928421241852827,
+	0.24633986350126363, 0.24340801542275012, 0.24048860594050039, 0.23758157443123795,
+	0.2346868618723299, 0.23180441082433859, 0.22893416541468023, 0.2260760713223802,
+	0.22323007576391746, 0.22039612748015194, 0.21757417672433113, 0.21476417525117358,
+	0.21196607630703015, 0.20917983462112499, 0.20640540639788071, 0.20364274931033485,
+	0.20089182249465656, 0.19815258654577511, 0.19542500351413428, 0.19270903690358912,
+	0.19000465167046496, 0.18731181422380025, 0.18463049242679927, 0.18196065559952257,
+	0.17930227452284767, 0.176655321443735, 0.17401977008183875, 0.17139559563750595,
+	0.16878277480121151, 0.16618128576448205, 0.1635911082323657, 0.16101222343751107,
+	0.15844461415592431, 0.15588826472447923, 0.15334316106026283, 0.15080929068184568,
+	0.14828664273257453, 0.14577520800599403, 0.14327497897351341, 0.1407859498144447,
+	0.13830811644855071, 0.13584147657125373, 0.13338602969166913, 0.1309417771736443,
+	0.12850872227999952, 0.12608687022018586, 0.12367622820159654, 0.12127680548479021,
+	0.11888861344290998, 0.1165116656256108, 0.11414597782783835, 0.11179156816383801,
+	0.10944845714681163, 0.10711666777468364, 0.1047962256224869, 0.10248715894193508,
+	0.10018949876880981, 0.097903279038862284, 0.095628536713008819, 0.09336531191269086,
+	0.091113648066373634, 0.088873592068275789, 0.086645194450557961, 0.084428509570353374,
+	0.082223595813202863, 0.080030515814663056, 0.077849336702096053, 0.075680130358927067,
+	0.073522973713981268, 0.071377949058890375, 0.069245144397006769, 0.067124653827788497,
+	0.065016577971242842, 0.062921024437758113, 0.060838108349539864, 0.058767952920933758,
+	0.056710690106202902, 0.054666461324888914, 0.052635418276792176, 0.050617723860947761,
+	0.048613553215868521, 0.046623094901930368, 0.044646552251294443, 0.042684144916474431,
+	0.040736110655940933, 0.038802707404526113, 0.036884215688567284, 0.034980941461716084,
+	0.033093219458578522, 0.031221417191920245, 0.029365939758133314, 0.027527235669603082,
+	0.025705804008548896, 0.023902203305795882, 0.022117062707308864, 0.020351096230044517,
+	0.018605121275724643, 0.016880083152543166, 0.015177088307935325, 0.01349745060173988,
+	0.011842757857907888, 0.010214971439701471, 0.0086165827693987316, 0.0070508754713732268,
+	0.0055224032992509968, 0.0040379725933630305, 0.0026090727461021627, 0.0012602859304985975,
+}
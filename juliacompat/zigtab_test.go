@@ -0,0 +1,27 @@
+package juliacompat
+
+import (
+	"math"
+	"testing"
+)
+
+// every level of the Ziggurat has the same area
+func TestZigTab(t *testing.T) {
+	x := func(i int) float64 { return wi[i] * (1 << 51) } // right edge of level i
+	v := x(0) * fi[255]                                   // area of the base level
+	for i := 1; i < 255; i++ {
+		if a := x(i+1) * (fi[i] - fi[i+1]); math.Abs(a-v) > 1e-12 {
+			t.Errorf("invalid area of level %d: expected %v, actual %v", i, v, a)
+		}
+		// the table is rounded from the exact values; off by one at most
+		if k := uint64(x(i) / x(i+1) * (1 << 51)); k+1 < ki[i+1] || k > ki[i+1]+1 {
+			t.Errorf("invalid ki[%d]: expected %#x, actual %#x", i+1, k, ki[i+1])
+		}
+	}
+	if math.Abs(x(255)-zigR) > 1e-15 || math.Abs(fi[255]-math.Exp(-0.5*zigR*zigR)) > 1e-18 {
+		t.Errorf("invalid right-most step: %v", x(255))
+	}
+	if ki[1] != 0 || fi[0] != 1 {
+		t.Errorf("invalid top level")
+	}
+}
@@ -0,0 +1,515 @@
+/*
+	locked.go
+	generators safe for concurrent use
+
+	LockedMT32 and LockedMT64 guard a generator with a mutex, and have the same methods with the generator.
+	MT32Pool and MT64Pool are sets of generators on streams 2^64 numbers apart, made by jumping from the seed.
+	The calls of a pool take no lock. A call owns a generator by an atomic flag while it generates:
+	it tries the generator last used on its P, kept by a sync.Pool, then the other generators of the pool,
+	and a mutex is taken only to make a new generator on the next stream when all of them are in use.
+	Which generator serves a call depends on the scheduling, so the numbers from a pool are not reproducible.
+*/
+
+package mtrand
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// LockedMT32 is an MT32 with a mutex
+type LockedMT32 struct {
+	mu sync.Mutex
+	mt *MT32
+}
+
+// NewLockedMT32() wraps mt. mt must not be used directly after this.
+func NewLockedMT32(mt *MT32) *LockedMT32 {
+	return &LockedMT32{mt: mt}
+}
+
+// Init() is MT32.Init() under the lock
+func (l *LockedMT32) Init(seed uint32) {
+	l.mu.Lock()
+	l.mt.Init(seed)
+	l.mu.Unlock()
+}
+
+// InitByArray() is MT32.InitByArray() under the lock
+func (l *LockedMT32) InitByArray(key []uint32) {
+	l.mu.Lock()
+	l.mt.InitByArray(key)
+	l.mu.Unlock()
+}
+
+// InitBySeedSeq() is MT32.InitBySeedSeq() under the lock
+func (l *LockedMT32) InitBySeedSeq(seq *SeedSeq) {
+	l.mu.Lock()
+	l.mt.InitBySeedSeq(seq)
+	l.mu.Unlock()
+}
+
+// InitByState() is MT32.InitByState() under the lock
+func (l *LockedMT32) InitByState(state []uint32, i int) {
+	l.mu.Lock()
+	l.mt.InitByState(state, i)
+	l.mu.Unlock()
+}
+
+// State() is MT32.State() under the lock
+func (l *LockedMT32) State() (state []uint32, i int) {
+	l.mu.Lock()
+	state, i = l.mt.State()
+	l.mu.Unlock()
+	return
+}
+
+// SeedPolicy() is MT32.SeedPolicy() under the lock
+func (l *LockedMT32) SeedPolicy() SeedPolicy {
+	l.mu.Lock()
+	p := l.mt.SeedPolicy()
+	l.mu.Unlock()
+	return p
+}
+
+// LockedMT32.MarshalBinary() implements encoding.BinaryMarshaler, with the encoding of MT32
+func (l *LockedMT32) MarshalBinary() ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.mt.MarshalBinary()
+}
+
+// LockedMT32.UnmarshalBinary() implements encoding.BinaryUnmarshaler
+func (l *LockedMT32) UnmarshalBinary(data []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.mt.UnmarshalBinary(data)
+}
+
+// Jump() is MT32.Jump() under the lock
+func (l *LockedMT32) Jump(mulStep uint64, baseStep uint) {
+	l.mu.Lock()
+	l.mt.Jump(mulStep, baseStep)
+	l.mu.Unlock()
+}
+
+// GenUint32() is MT32.GenUint32() under the lock
+func (l *LockedMT32) GenUint32() uint32 {
+	l.mu.Lock()
+	v := l.mt.GenUint32()
+	l.mu.Unlock()
+	return v
+}
+
+// GenInt31() is MT32.GenInt31() under the lock
+func (l *LockedMT32) GenInt31() int32 {
+	l.mu.Lock()
+	v := l.mt.GenInt31()
+	l.mu.Unlock()
+	return v
+}
+
+// GenReal1() is MT32.GenReal1() under the lock
+func (l *LockedMT32) GenReal1() float64 {
+	l.mu.Lock()
+	v := l.mt.GenReal1()
+	l.mu.Unlock()
+	return v
+}
+
+// GenReal2() is MT32.GenReal2() under the lock
+func (l *LockedMT32) GenReal2() float64 {
+	l.mu.Lock()
+	v := l.mt.GenReal2()
+	l.mu.Unlock()
+	return v
+}
+
+// GenReal3() is MT32.GenReal3() under the lock
+func (l *LockedMT32) GenReal3() float64 {
+	l.mu.Lock()
+	v := l.mt.GenReal3()
+	l.mu.Unlock()
+	return v
+}
+
+// GenRes53() is MT32.GenRes53() under the lock
+func (l *LockedMT32) GenRes53() float64 {
+	l.mu.Lock()
+	v := l.mt.GenRes53()
+	l.mu.Unlock()
+	return v
+}
+
+// LockedMT32.Seed() is an interface member for math/rand
+func (l *LockedMT32) Seed(seed int64) {
+	l.mu.Lock()
+	l.mt.Seed(seed)
+	l.mu.Unlock()
+}
+
+// LockedMT32.Int63() is an interface member for math/rand
+func (l *LockedMT32) Int63() int64 {
+	l.mu.Lock()
+	v := l.mt.Int63()
+	l.mu.Unlock()
+	return v
+}
+
+// LockedMT32.Uint64() is an interface member for math/rand and math/rand/v2
+func (l *LockedMT32) Uint64() uint64 {
+	l.mu.Lock()
+	v := l.mt.Uint64()
+	l.mu.Unlock()
+	return v
+}
+
+// LockedMT32.Read() is an io.Reader interface for crypto/rand
+func (l *LockedMT32) Read(buf []byte) (n int, err error) {
+	l.mu.Lock()
+	n, err = l.mt.Read(buf)
+	l.mu.Unlock()
+	return
+}
+
+// LockedMT64 is an MT64 with a mutex
+type LockedMT64 struct {
+	mu sync.Mutex
+	mt *MT64
+}
+
+// NewLockedMT64() wraps mt. mt must not be used directly after this.
+func NewLockedMT64(mt *MT64) *LockedMT64 {
+	return &LockedMT64{mt: mt}
+}
+
+// Init() is MT64.Init() under the lock
+func (l *LockedMT64) Init(seed uint64) {
+	l.mu.Lock()
+	l.mt.Init(seed)
+	l.mu.Unlock()
+}
+
+// InitByArray() is MT64.InitByArray() under the lock
+func (l *LockedMT64) InitByArray(key []uint64) {
+	l.mu.Lock()
+	l.mt.InitByArray(key)
+	l.mu.Unlock()
+}
+
+// InitBySeedSeq() is MT64.InitBySeedSeq() under the lock
+func (l *LockedMT64) InitBySeedSeq(seq *SeedSeq) {
+	l.mu.Lock()
+	l.mt.InitBySeedSeq(seq)
+	l.mu.Unlock()
+}
+
+// InitByState() is MT64.InitByState() under the lock
+func (l *LockedMT64) InitByState(state []uint64, i int) {
+	l.mu.Lock()
+	l.mt.InitByState(state, i)
+	l.mu.Unlock()
+}
+
+// State() is MT64.State() under the lock
+func (l *LockedMT64) State() (state []uint64, i int) {
+	l.mu.Lock()
+	state, i = l.mt.State()
+	l.mu.Unlock()
+	return
+}
+
+// SeedPolicy() is MT64.SeedPolicy() under the lock
+func (l *LockedMT64) SeedPolicy() SeedPolicy {
+	l.mu.Lock()
+	p := l.mt.SeedPolicy()
+	l.mu.Unlock()
+	return p
+}
+
+// LockedMT64.MarshalBinary() implements encoding.BinaryMarshaler, with the encoding of MT64
+func (l *LockedMT64) MarshalBinary() ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.mt.MarshalBinary()
+}
+
+// LockedMT64.UnmarshalBinary() implements encoding.BinaryUnmarshaler
+func (l *LockedMT64) UnmarshalBinary(data []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.mt.UnmarshalBinary(data)
+}
+
+// Jump() is MT64.Jump() under the lock
+func (l *LockedMT64) Jump(mulStep uint64, baseStep uint) {
+	l.mu.Lock()
+	l.mt.Jump(mulStep, baseStep)
+	l.mu.Unlock()
+}
+
+// GenUint64() is MT64.GenUint64() under the lock
+func (l *LockedMT64) GenUint64() uint64 {
+	l.mu.Lock()
+	v := l.mt.GenUint64()
+	l.mu.Unlock()
+	return v
+}
+
+// GenInt63() is MT64.GenInt63() under the lock
+func (l *LockedMT64) GenInt63() int64 {
+	l.mu.Lock()
+	v := l.mt.GenInt63()
+	l.mu.Unlock()
+	return v
+}
+
+// GenReal1() is MT64.GenReal1() under the lock
+func (l *LockedMT64) GenReal1() float64 {
+	l.mu.Lock()
+	v := l.mt.GenReal1()
+	l.mu.Unlock()
+	return v
+}
+
+// GenReal2() is MT64.GenReal2() under the lock
+func (l *LockedMT64) GenReal2() float64 {
+	l.mu.Lock()
+	v := l.mt.GenReal2()
+	l.mu.Unlock()
+	return v
+}
+
+// GenReal3() is MT64.GenReal3() under the lock
+func (l *LockedMT64) GenReal3() float64 {
+	l.mu.Lock()
+	v := l.mt.GenReal3()
+	l.mu.Unlock()
+	return v
+}
+
+// LockedMT64.Seed() is an interface member for math/rand
+func (l *LockedMT64) Seed(seed int64) {
+	l.mu.Lock()
+	l.mt.Seed(seed)
+	l.mu.Unlock()
+}
+
+// LockedMT64.Int63() is an interface member for math/rand
+func (l *LockedMT64) Int63() int64 {
+	l.mu.Lock()
+	v := l.mt.Int63()
+	l.mu.Unlock()
+	return v
+}
+
+// LockedMT64.Uint64() is an interface member for math/rand and math/rand/v2
+func (l *LockedMT64) Uint64() uint64 {
+	l.mu.Lock()
+	v := l.mt.Uint64()
+	l.mu.Unlock()
+	return v
+}
+
+// LockedMT64.Read() is an io.Reader interface for crypto/rand
+func (l *LockedMT64) Read(buf []byte) (n int, err error) {
+	l.mu.Lock()
+	n, err = l.mt.Read(buf)
+	l.mu.Unlock()
+	return
+}
+
+//
+// pools
+//
+
+// distance of the streams of a pool, 2^poolJumpBase numbers
+const poolJumpBase = 64
+
+// MT32Pool is a set of MT32 on separate streams, shared by goroutines without locks
+type MT32Pool struct {
+	epoch uint32       // incremented by Seed()
+	all   atomic.Value // []*pooledMT32; the generators of the epoch
+	hint  sync.Pool    // the generator last used on a P
+	mu    sync.Mutex   // taken to make a new generator, and by Seed()
+	next  *MT32        // the stream of the next new generator
+}
+
+type pooledMT32 struct {
+	busy  uint32 // 1 while a call owns the generator
+	epoch uint32
+	mt    *MT32
+}
+
+// NewMT32Pool() creates a pool of which the k-th generator made is on the stream of Init(seed) jumped by k*2^64 numbers
+func NewMT32Pool(seed uint32) *MT32Pool {
+	mt := NewMT32()
+	mt.Init(seed)
+	p := &MT32Pool{next: mt}
+	p.all.Store([]*pooledMT32(nil))
+	return p
+}
+
+// own an idle generator of the current epoch, or make a new one
+func (p *MT32Pool) get() *pooledMT32 {
+	e := atomic.LoadUint32(&p.epoch)
+	if g, _ := p.hint.Get().(*pooledMT32); g != nil && g.epoch == e && atomic.CompareAndSwapUint32(&g.busy, 0, 1) {
+		return g
+	}
+	for _, g := range p.all.Load().([]*pooledMT32) {
+		if g.epoch == e && atomic.CompareAndSwapUint32(&g.busy, 0, 1) {
+			return g
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	g := &pooledMT32{busy: 1, epoch: p.epoch, mt: NewMT32()}
+	g.mt.InitByState(p.next.State())
+	p.next.Jump(1, poolJumpBase)
+	all := p.all.Load().([]*pooledMT32)
+	p.all.Store(append(all[:len(all):len(all)], g))
+	return g
+}
+
+// release the generator
+func (p *MT32Pool) put(g *pooledMT32) {
+	atomic.StoreUint32(&g.busy, 0)
+	p.hint.Put(g)
+}
+
+// MT32Pool.Seed() is an interface member for math/rand.
+// The pool restarts with new generators on the streams of MT32.Seed(seed), with SeedDefault.
+func (p *MT32Pool) Seed(seed int64) {
+	mt := NewMT32()
+	mt.Seed(seed)
+	p.mu.Lock()
+	p.next = mt
+	p.all.Store([]*pooledMT32(nil))
+	atomic.AddUint32(&p.epoch, 1)
+	p.mu.Unlock()
+}
+
+// Do() calls f with a generator of the pool, which is owned by f until f returns
+func (p *MT32Pool) Do(f func(mt *MT32)) {
+	g := p.get()
+	f(g.mt)
+	p.put(g)
+}
+
+// MT32Pool.GenUint32() generates a number by a generator of the pool
+func (p *MT32Pool) GenUint32() uint32 {
+	g := p.get()
+	v := g.mt.GenUint32()
+	p.put(g)
+	return v
+}
+
+// MT32Pool.Int63() is an interface member for math/rand
+func (p *MT32Pool) Int63() int64 {
+	g := p.get()
+	v := g.mt.Int63()
+	p.put(g)
+	return v
+}
+
+// MT32Pool.Uint64() is an interface member for math/rand and math/rand/v2
+func (p *MT32Pool) Uint64() uint64 {
+	g := p.get()
+	v := g.mt.Uint64()
+	p.put(g)
+	return v
+}
+
+// MT64Pool is a set of MT64 on separate streams, shared by goroutines without locks
+type MT64Pool struct {
+	epoch uint32       // incremented by Seed()
+	all   atomic.Value // []*pooledMT64; the generators of the epoch
+	hint  sync.Pool    // the generator last used on a P
+	mu    sync.Mutex   // taken to make a new generator, and by Seed()
+	next  *MT64        // the stream of the next new generator
+}
+
+type pooledMT64 struct {
+	busy  uint32 // 1 while a call owns the generator
+	epoch uint32
+	mt    *MT64
+}
+
+// NewMT64Pool() creates a pool of which the k-th generator made is on the stream of Init(seed) jumped by k*2^64 numbers
+func NewMT64Pool(seed uint64) *MT64Pool {
+	mt := NewMT64()
+	mt.Init(seed)
+	p := &MT64Pool{next: mt}
+	p.all.Store([]*pooledMT64(nil))
+	return p
+}
+
+// own an idle generator of the current epoch, or make a new one
+func (p *MT64Pool) get() *pooledMT64 {
+	e := atomic.LoadUint32(&p.epoch)
+	if g, _ := p.hint.Get().(*pooledMT64); g != nil && g.epoch == e && atomic.CompareAndSwapUint32(&g.busy, 0, 1) {
+		return g
+	}
+	for _, g := range p.all.Load().([]*pooledMT64) {
+		if g.epoch == e && atomic.CompareAndSwapUint32(&g.busy, 0, 1) {
+			return g
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	g := &pooledMT64{busy: 1, epoch: p.epoch, mt: NewMT64()}
+	g.mt.InitByState(p.next.State())
+	p.next.Jump(1, poolJumpBase)
+	all := p.all.Load().([]*pooledMT64)
+	p.all.Store(append(all[:len(all):len(all)], g))
+	return g
+}
+
+// release the generator
+func (p *MT64Pool) put(g *pooledMT64) {
+	atomic.StoreUint32(&g.busy, 0)
+	p.hint.Put(g)
+}
+
+// MT64Pool.Seed() is an interface member for math/rand.
+// The pool restarts with new generators on the streams of MT64.Seed(seed), with SeedDefault.
+func (p *MT64Pool) Seed(seed int64) {
+	mt := NewMT64()
+	mt.Seed(seed)
+	p.mu.Lock()
+	p.next = mt
+	p.all.Store([]*pooledMT64(nil))
+	atomic.AddUint32(&p.epoch, 1)
+	p.mu.Unlock()
+}
+
+// Do() calls f with a generator of the pool, which is owned by f until f returns
+func (p *MT64Pool) Do(f func(mt *MT64)) {
+	g := p.get()
+	f(g.mt)
+	p.put(g)
+}
+
+// MT64Pool.GenUint64() generates a number by a generator of the pool
+func (p *MT64Pool) GenUint64() uint64 {
+	g := p.get()
+	v := g.mt.GenUint64()
+	p.put(g)
+	return v
+}
+
+// MT64Pool.Int63() is an interface member for math/rand
+func (p *MT64Pool) Int63() int64 {
+	g := p.get()
+	v := g.mt.GenInt63()
+	p.put(g)
+	return v
+}
+
+// MT64Pool.Uint64() is an interface member for math/rand and math/rand/v2
+func (p *MT64Pool) Uint64() uint64 {
+	g := p.get()
+	v := g.mt.GenUint64()
+	p.put(g)
+	return v
+}
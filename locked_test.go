@@ -0,0 +1,231 @@
+package mtrand_test
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+)
+
+// concurrent goroutines share the sequence of a locked generator
+func TestLocked(t *testing.T) {
+	const g, n = 8, 10000
+	l := mtrand.NewLockedMT64(mtrand.NewMT64())
+	l.Init(1)
+	out := make([][]uint64, g)
+	var wg sync.WaitGroup
+	for i := range out {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < n; j++ {
+				out[i] = append(out[i], l.GenUint64())
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var all []uint64
+	for _, o := range out {
+		all = append(all, o...)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+	mt := mtrand.NewMT64()
+	mt.Init(1)
+	expected := make([]uint64, g*n)
+	for i := range expected {
+		expected[i] = mt.GenUint64()
+	}
+	sort.Slice(expected, func(i, j int) bool { return expected[i] < expected[j] })
+	for i := range expected {
+		if all[i] != expected[i] {
+			t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, expected[i], all[i])
+		}
+	}
+}
+
+// locked generators save and restore the states of their generators
+func TestLockedState(t *testing.T) {
+	l := mtrand.NewLockedMT32(mtrand.NewMT32WithSeedPolicy(mtrand.SeedSeedSeq))
+	l.InitBySeedSeq(mtrand.NewSeedSeq(1, 2, 3))
+	mt := mtrand.NewMT32()
+	mt.InitBySeedSeq(mtrand.NewSeedSeq(1, 2, 3))
+	for i := 0; i < 10; i++ {
+		if v, r := mt.GenUint32(), l.GenUint32(); v != r {
+			t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+	}
+	b, err := l.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, i := l.State()
+	l2 := mtrand.NewLockedMT32(mtrand.NewMT32())
+	if err := l2.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if p := l2.SeedPolicy(); p != mtrand.SeedSeedSeq {
+		t.Errorf("invalid policy: expected %v, actual %v", mtrand.SeedSeedSeq, p)
+	}
+	l3 := mtrand.NewLockedMT32(mtrand.NewMT32())
+	l3.InitByState(state, i)
+	for i := 0; i < 1000; i++ {
+		v := l.GenUint32()
+		if r := l2.GenUint32(); v != r {
+			t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+		if r := l3.GenUint32(); v != r {
+			t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+	}
+
+	l64 := mtrand.NewLockedMT64(mtrand.NewMT64())
+	l64.InitBySeedSeq(mtrand.NewSeedSeq(4))
+	m64 := mtrand.NewMT64()
+	m64.InitBySeedSeq(mtrand.NewSeedSeq(4))
+	b, err = l64.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r64 := mtrand.NewLockedMT64(mtrand.NewMT64())
+	if err := r64.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	r64b := mtrand.NewLockedMT64(mtrand.NewMT64())
+	r64b.InitByState(l64.State())
+	for i := 0; i < 1000; i++ {
+		v := m64.GenUint64()
+		if r := r64.GenUint64(); v != r {
+			t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+		if r := r64b.GenUint64(); v != r {
+			t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+	}
+}
+
+// generators of a pool are on streams 2^64 numbers apart
+func TestPool(t *testing.T) {
+	// the first generator is on the stream of the seed
+	p := mtrand.NewMT64Pool(1)
+	mt := mtrand.NewMT64()
+	mt.Init(1)
+	p.Do(func(g *mtrand.MT64) {
+		for i := 0; i < 1000; i++ {
+			if v, r := mt.GenUint64(), g.GenUint64(); v != r {
+				t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+			}
+		}
+	})
+
+	// a generator owned by Do() is not shared; a nested call makes the generator of the next stream
+	q := mtrand.NewMT32Pool(1)
+	m := mtrand.NewMT32()
+	m.Init(1)
+	m2 := mtrand.NewMT32()
+	m2.InitByState(m.State())
+	m2.Jump(1, 64)
+	q.Do(func(a *mtrand.MT32) {
+		q.Do(func(b *mtrand.MT32) {
+			if a == b {
+				t.Fatalf("a generator is shared")
+			}
+			for i := 0; i < 1000; i++ {
+				if v, r := m.GenUint32(), a.GenUint32(); v != r {
+					t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+				}
+				if v, r := m2.GenUint32(), b.GenUint32(); v != r {
+					t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+				}
+			}
+		})
+	})
+
+	// Seed() restarts the pool; a pool is a Source of math/rand
+	var src rand.Source = p
+	src.Seed(-5)
+	mt = mtrand.NewMT64()
+	mt.Seed(-5)
+	p.Do(func(g *mtrand.MT64) {
+		for i := 0; i < 1000; i++ {
+			if v, r := mt.GenUint64(), g.GenUint64(); v != r {
+				t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+			}
+		}
+	})
+	q.Seed(1<<32 + 1)
+	m = mtrand.NewMT32()
+	m.Seed(1<<32 + 1)
+	q.Do(func(g *mtrand.MT32) {
+		for i := 0; i < 1000; i++ {
+			if v, r := m.GenUint32(), g.GenUint32(); v != r {
+				t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+			}
+		}
+	})
+
+	// concurrent use, with Seed() in between
+	p = mtrand.NewMT64Pool(1)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 10000; j++ {
+				p.Uint64()
+				q.Uint64()
+			}
+			p.Do(func(mt *mtrand.MT64) { mt.GenReal2() })
+			if i == 0 {
+				q.Seed(int64(i))
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// Benchmarks of the generators under contention; run with -cpu to change the number of goroutines,
+// and with -race to check the synchronization.
+
+func BenchmarkMT64(b *testing.B) {
+	mt := mtrand.NewMT64()
+	for i := 0; i < b.N; i++ {
+		mt.GenUint64()
+	}
+}
+
+func BenchmarkLockedMT64Parallel(b *testing.B) {
+	l := mtrand.NewLockedMT64(mtrand.NewMT64())
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			l.GenUint64()
+		}
+	})
+}
+
+func BenchmarkMT64PoolParallel(b *testing.B) {
+	p := mtrand.NewMT64Pool(1)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			p.GenUint64()
+		}
+	})
+}
+
+func BenchmarkMT64PoolDoParallel(b *testing.B) {
+	p := mtrand.NewMT64Pool(1)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			// a batch of numbers for a get and a put of the pool
+			p.Do(func(mt *mtrand.MT64) {
+				for i := 0; i < 16; i++ {
+					mt.GenUint64()
+				}
+			})
+		}
+	})
+}
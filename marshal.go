@@ -0,0 +1,103 @@
+/*
+	marshal.go
+	binary encoding of the states, like the sources of math/rand/v2
+
+	The encoding ends with a byte of the SeedPolicy.
+*/
+
+package mtrand
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var (
+	errInvalidMT32State = errors.New("mtrand: invalid MT32 encoding")
+	errInvalidMT64State = errors.New("mtrand: invalid MT64 encoding")
+)
+
+const (
+	mt32Magic = "mt32:"
+	mt64Magic = "mt64:"
+)
+
+// mt32.MarshalBinary() implements encoding.BinaryMarshaler; the state words and the index in big-endian,
+// and the seed policy
+func (mt *MT32) MarshalBinary() ([]byte, error) {
+	b := make([]byte, len(mt32Magic)+4+4*mt32N+1)
+	copy(b, mt32Magic)
+	p := b[len(mt32Magic):]
+	binary.BigEndian.PutUint32(p, uint32(mt.i))
+	for j, v := range mt.mt {
+		binary.BigEndian.PutUint32(p[4+4*j:], v)
+	}
+	b[len(b)-1] = byte(mt.policy)
+	return b, nil
+}
+
+// mt32.UnmarshalBinary() implements encoding.BinaryUnmarshaler
+func (mt *MT32) UnmarshalBinary(data []byte) error {
+	if len(data) != len(mt32Magic)+4+4*mt32N+1 || string(data[:len(mt32Magic)]) != mt32Magic {
+		return errInvalidMT32State
+	}
+	policy := SeedPolicy(data[len(data)-1])
+	if policy > SeedNumPyLegacy {
+		return errInvalidMT32State
+	}
+	data = data[len(mt32Magic):]
+	i := int(binary.BigEndian.Uint32(data))
+	if i > mt32N+1 {
+		return errInvalidMT32State
+	}
+	data = data[4:]
+	if mt.mt == nil {
+		mt.mt = make([]uint32, mt32N)
+	}
+	for j := range mt.mt {
+		mt.mt[j] = binary.BigEndian.Uint32(data[4*j:])
+	}
+	mt.i = i
+	mt.policy = policy
+	return nil
+}
+
+// mt64.MarshalBinary() implements encoding.BinaryMarshaler; the state words and the index in big-endian,
+// and the seed policy
+func (mt *MT64) MarshalBinary() ([]byte, error) {
+	b := make([]byte, len(mt64Magic)+4+8*mt64NN+1)
+	copy(b, mt64Magic)
+	p := b[len(mt64Magic):]
+	binary.BigEndian.PutUint32(p, uint32(mt.i))
+	for j, v := range mt.mt {
+		binary.BigEndian.PutUint64(p[4+8*j:], v)
+	}
+	b[len(b)-1] = byte(mt.policy)
+	return b, nil
+}
+
+// mt64.UnmarshalBinary() implements encoding.BinaryUnmarshaler
+func (mt *MT64) UnmarshalBinary(data []byte) error {
+	if len(data) != len(mt64Magic)+4+8*mt64NN+1 || string(data[:len(mt64Magic)]) != mt64Magic {
+		return errInvalidMT64State
+	}
+	policy := SeedPolicy(data[len(data)-1])
+	if policy >= SeedNumPyLegacy {
+		return errInvalidMT64State
+	}
+	data = data[len(mt64Magic):]
+	i := int(binary.BigEndian.Uint32(data))
+	if i > mt64NN+1 {
+		return errInvalidMT64State
+	}
+	data = data[4:]
+	if mt.mt == nil {
+		mt.mt = make([]uint64, mt64NN)
+	}
+	for j := range mt.mt {
+		mt.mt[j] = binary.BigEndian.Uint64(data[8*j:])
+	}
+	mt.i = i
+	mt.policy = policy
+	return nil
+}
@@ -0,0 +1,99 @@
+package mtrand_test
+
+import (
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+)
+
+// an unmarshaled generator continues the sequence of the marshaled one
+func TestMarshalBinary(t *testing.T) {
+	mt32 := mtrand.NewMT32()
+	mt32.Init(1)
+	for i := 0; i < 700; i++ {
+		mt32.GenUint32()
+	}
+	b, err := mt32.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r32 := &mtrand.MT32{}
+	if err := r32.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		if v, r := mt32.GenUint32(), r32.GenUint32(); v != r {
+			t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+	}
+
+	mt64 := mtrand.NewMT64()
+	mt64.Init(1)
+	for i := 0; i < 100; i++ {
+		mt64.GenUint64()
+	}
+	b, err = mt64.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r64 := mtrand.NewMT64()
+	if err := r64.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		if v, r := mt64.GenUint64(), r64.GenUint64(); v != r {
+			t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+	}
+
+	// encodings of other generators are rejected
+	if err := r32.UnmarshalBinary(b); err == nil {
+		t.Errorf("MT64 encoding is accepted by MT32")
+	}
+	if err := r64.UnmarshalBinary(b[:len(b)-1]); err == nil {
+		t.Errorf("short encoding is accepted")
+	}
+}
+
+// the seed policy is encoded
+func TestMarshalSeedPolicy(t *testing.T) {
+	mt32 := mtrand.NewMT32WithSeedPolicy(mtrand.SeedNumPyLegacy)
+	b, err := mt32.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r32 := mtrand.NewMT32()
+	if err := r32.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if p := r32.SeedPolicy(); p != mtrand.SeedNumPyLegacy {
+		t.Errorf("invalid policy: expected %v, actual %v", mtrand.SeedNumPyLegacy, p)
+	}
+	b[len(b)-1] = 0xff
+	if err := r32.UnmarshalBinary(b); err == nil {
+		t.Errorf("invalid policy is accepted")
+	}
+
+	mt64 := mtrand.NewMT64WithSeedPolicy(mtrand.SeedSeedSeq)
+	mt64.Seed(3)
+	b, err = mt64.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r64 := mtrand.NewMT64()
+	if err := r64.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if p := r64.SeedPolicy(); p != mtrand.SeedSeedSeq {
+		t.Errorf("invalid policy: expected %v, actual %v", mtrand.SeedSeedSeq, p)
+	}
+	mt64.Seed(5)
+	r64.Seed(5)
+	if v, r := mt64.GenUint64(), r64.GenUint64(); v != r {
+		t.Errorf("invalid value after Seed(): expected %v, actual %v", v, r)
+	}
+	b[len(b)-1] = byte(mtrand.SeedNumPyLegacy)
+	if err := r64.UnmarshalBinary(b); err == nil {
+		t.Errorf("SeedNumPyLegacy is accepted by MT64")
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Package matlabcompat reproduces the 'twister' generators of MATLAB and GNU Octave on a Mersenne Twister of mtrand.
+
+Both are MT19937, the same generator with mtrand.MT32, with different seeding, conversion to doubles and state vectors.
+MATLAB generates the same numbers with MATLAB's rng(seed, 'twister'),
+and Octave generates the same numbers with Octave's rand('twister', seed).
+
+	m := matlabcompat.NewMATLAB(mtrand.NewMT32())
+	m.Rng(0)              // rng(0, 'twister'), or rng default
+	x := m.Rand()         // rand
+	n := m.Randi(1, 10)   // randi(10)
+
+Randperm() returns 0-based indices, where MATLAB and Octave return 1-based ones.
+Like math/rand, invalid arguments cause a panic, where MATLAB and Octave raise an error.
+*/
+package matlabcompat
+
+import (
+	"math"
+	"sort"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+)
+
+const n = 624
+
+// randi() of both; floor(rand * (imax-imin+1)) + imin
+func randi(u float64, imin, imax int64) int64 {
+	if imax < imin {
+		panic("matlabcompat: imin must be less than or equal to imax")
+	}
+	return imin + int64(math.Floor(u*float64(imax-imin+1)))
+}
+
+//
+// MATLAB
+//
+
+// MATLAB is a random number generator with the algorithms of MATLAB's 'twister'
+type MATLAB struct {
+	mt *mtrand.MT32
+}
+
+// NewMATLAB() creates a MATLAB on mt. The state of mt is used as is until Rng() or SetState() is called.
+func NewMATLAB(mt *mtrand.MT32) *MATLAB {
+	return &MATLAB{mt: mt}
+}
+
+// MT32() returns the underlying generator
+func (m *MATLAB) MT32() *mtrand.MT32 {
+	return m.mt
+}
+
+// Rng() is rng(seed, 'twister'); the seed 0, the default of MATLAB, is 5489
+func (m *MATLAB) Rng(seed uint32) {
+	if seed == 0 {
+		seed = 5489
+	}
+	m.mt.Init(seed)
+}
+
+// Rand() is rand; a number with 53-bit resolution, the same with MT32.GenRes53()
+func (m *MATLAB) Rand() float64 {
+	return m.mt.GenRes53()
+}
+
+// Randi() is randi([imin, imax])
+func (m *MATLAB) Randi(imin, imax int64) int64 {
+	return randi(m.Rand(), imin, imax)
+}
+
+// Randperm() is randperm(n); the order of sorted rand(1, n)
+func (m *MATLAB) Randperm(size int) []int {
+	u := make([]float64, size)
+	p := make([]int, size)
+	for i := range u {
+		u[i] = m.Rand()
+		p[i] = i
+	}
+	sort.SliceStable(p, func(i, j int) bool { return u[p[i]] < u[p[j]] })
+	return p
+}
+
+// State() is the State field of rng; 624 state words followed by the index of the next word
+func (m *MATLAB) State() []uint32 {
+	state, i := m.mt.State()
+	if i > n {
+		m.Rng(0)
+		state, i = m.mt.State()
+	}
+	return append(state, uint32(i))
+}
+
+// SetState() restores a vector of State()
+func (m *MATLAB) SetState(s []uint32) {
+	if len(s) != n+1 || s[n] > n {
+		panic("matlabcompat: invalid state")
+	}
+	m.mt.InitByState(s[:n], int(s[n]))
+}
+
+//
+// Octave
+//
+
+// Octave is a random number generator with the algorithms of Octave's 'twister'
+type Octave struct {
+	mt *mtrand.MT32
+}
+
+// NewOctave() creates an Octave on mt. The state of mt is used as is until SetTwister() is called.
+func NewOctave(mt *mtrand.MT32) *Octave {
+	return &Octave{mt: mt}
+}
+
+// MT32() returns the underlying generator
+func (o *Octave) MT32() *mtrand.MT32 {
+	return o.mt
+}
+
+// double2uint32() of Octave; modulo 2^32-1, not 2^32
+func double2uint32(d float64) uint32 {
+	const twoUp32 = math.MaxUint32
+	if math.IsInf(d, 0) || math.IsNaN(d) {
+		return 0
+	}
+	d = math.Mod(d, twoUp32)
+	if d < 0 {
+		d += twoUp32
+	}
+	return uint32(d)
+}
+
+// SetTwister() is rand('twister', v).
+// A vector of Twister() restores the state, and other vectors and scalars are keys of init_by_array().
+// Like Octave, only the first 625 words of a longer vector are used.
+func (o *Octave) SetTwister(v ...float64) {
+	if len(v) > n+1 {
+		v = v[:n+1]
+	}
+	s := make([]uint32, len(v))
+	for i, d := range v {
+		s[i] = double2uint32(d)
+	}
+	if len(s) == n+1 && s[n] <= n && s[n] > 0 {
+		// the last word is the number of words left, plus 1
+		o.mt.InitByState(s[:n], n+1-int(s[n]))
+		return
+	}
+	if len(s) == 0 {
+		panic("matlabcompat: empty seed")
+	}
+	o.mt.InitByArray(s)
+}
+
+// Twister() is rand('twister'); 624 state words followed by the number of words left plus 1
+func (o *Octave) Twister() []float64 {
+	state, i := o.mt.State()
+	if i > n {
+		o.mt.Init(5489)
+		state, i = o.mt.State()
+	}
+	v := make([]float64, n+1)
+	for j, w := range state {
+		v[j] = float64(w)
+	}
+	v[n] = float64(n + 1 - i)
+	return v
+}
+
+// Rand() is rand; a number on (0, 1) with 53-bit resolution, shifted by 0.4 of the resolution from 0
+func (o *Octave) Rand() float64 {
+	a, b := o.mt.GenUint32()>>5, o.mt.GenUint32()>>6
+	return (float64(float64(a)*67108864.0) + float64(b) + 0.4) / 9007199254740992.0
+}
+
+// Randi() is randi([imin, imax])
+func (o *Octave) Randi(imin, imax int64) int64 {
+	return randi(o.Rand(), imin, imax)
+}
+
+// Randperm() is randperm(n, m); the first m numbers of a Knuth shuffle, with m numbers of rand drawn first
+func (o *Octave) Randperm(size, m int) []int {
+	if m < 0 || m > size {
+		panic("matlabcompat: m must be between 0 and n")
+	}
+	r := make([]float64, m)
+	for i := range r {
+		r[i] = o.Rand()
+	}
+	p := make([]int, size)
+	for i := range p {
+		p[i] = i
+	}
+	for i := 0; i < m; i++ {
+		k := i + int(math.Floor(r[i]*float64(size-i)))
+		p[i], p[k] = p[k], p[i]
+	}
+	return p[:m]
+}
@@ -0,0 +1,146 @@
+package matlabcompat_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/matlabcompat"
+)
+
+// compare with outputs of MATLAB, printed by format long
+func TestMATLAB(t *testing.T) {
+	m := matlabcompat.NewMATLAB(mtrand.NewMT32())
+	near := func(x, v float64) bool { return math.Abs(x-v) <= 5e-16 }
+
+	// rng default; rand(1, 5)
+	m.Rng(0)
+	target1 := []float64{0.814723686393179, 0.905791937075619, 0.126986816293506, 0.913375856139019, 0.632359246225410}
+	for i, v := range target1 {
+		if x := m.Rand(); !near(x, v) {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+
+	// rng(1); rand
+	m.Rng(1)
+	if x := m.Rand(); !near(x, 0.417022004702574) {
+		t.Errorf("invalid rand: expected %v, actual %v", 0.417022004702574, x)
+	}
+
+	// rng default; randi(10, 1, 5)
+	m.Rng(0)
+	target2 := []int64{9, 10, 2, 10, 7}
+	for i, v := range target2 {
+		if x := m.Randi(1, 10); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+
+	// rng default; randperm(8)
+	m.Rng(0)
+	p := m.Randperm(8)
+	for i := range p {
+		p[i]++
+	}
+	if target3 := []int{6, 3, 7, 8, 5, 1, 2, 4}; !reflect.DeepEqual(p, target3) {
+		t.Errorf("invalid randperm: expected %v, actual %v", target3, p)
+	}
+
+	// a saved state continues the sequence
+	m.Rng(3)
+	for i := 0; i < 700; i++ {
+		m.Rand()
+	}
+	s := m.State()
+	if len(s) != 625 || s[624] != 2*700%624 {
+		t.Errorf("invalid state: length %d, index %d", len(s), s[624])
+	}
+	m2 := matlabcompat.NewMATLAB(mtrand.NewMT32())
+	m2.SetState(s)
+	for i := 0; i < 1000; i++ {
+		if x, v := m2.Rand(), m.Rand(); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+			break
+		}
+	}
+}
+
+func TestOctave(t *testing.T) {
+	o := matlabcompat.NewOctave(mtrand.NewMT32())
+
+	// a scalar seed is init_by_array(), same with Python's random.seed(42) for a number of 0.5 or more
+	o.SetTwister(42)
+	if x := o.Rand(); x != 0.6394267984578837 {
+		t.Errorf("invalid rand: expected %v, actual %v", 0.6394267984578837, x)
+	}
+
+	// 0.4 is added to the 53-bit integer
+	o.SetTwister(7, 8)
+	mt := mtrand.NewMT32()
+	mt.InitByArray([]uint32{7, 8})
+	for i := 0; i < 10; i++ {
+		a, b := mt.GenUint32()>>5, mt.GenUint32()>>6
+		v := (float64(a)*67108864.0 + float64(b) + 0.4) / 9007199254740992.0
+		if x := o.Rand(); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+
+	// double2uint32 is modulo 2^32-1
+	o.SetTwister(-1)
+	mt.InitByArray([]uint32{0xffff_fffe})
+	if x, v := o.MT32().GenUint32(), mt.GenUint32(); x != v {
+		t.Errorf("invalid seed of -1: expected %x, actual %x", v, x)
+	}
+
+	// a state vector has the number of words left plus 1, and restores the state
+	o.SetTwister(1)
+	s := o.Twister()
+	if len(s) != 625 || s[624] != 1 {
+		t.Errorf("invalid state: length %d, left %v", len(s), s[624])
+	}
+	for i := 0; i < 30; i++ {
+		o.Rand()
+	}
+	s = o.Twister()
+	if s[624] != 624-60+1 {
+		t.Errorf("invalid left: %v", s[624])
+	}
+	o2 := matlabcompat.NewOctave(mtrand.NewMT32())
+	o2.SetTwister(s...)
+	for i := 0; i < 1000; i++ {
+		if x, v := o2.Rand(), o.Rand(); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+			break
+		}
+	}
+
+	// keys after the 625th are ignored
+	key := make([]float64, 700)
+	for i := range key {
+		key[i] = float64(1000 + i)
+	}
+	o.SetTwister(key...)
+	o2.SetTwister(key[:625]...)
+	for i := 0; i < 10; i++ {
+		if x, v := o.Rand(), o2.Rand(); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+
+	// randperm is a Knuth shuffle
+	o.SetTwister(5)
+	p := o.Randperm(10, 4)
+	o.SetTwister(5)
+	ref := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	r := []float64{o.Rand(), o.Rand(), o.Rand(), o.Rand()}
+	for i, u := range r {
+		k := i + int(u*float64(10-i))
+		ref[i], ref[k] = ref[k], ref[i]
+	}
+	if !reflect.DeepEqual(p, ref[:4]) {
+		t.Errorf("invalid randperm: expected %v, actual %v", ref[:4], p)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Package mklcompat reproduces basic random number generators of Intel MKL Vector Statistics on generators of mtrand.
+
+VSL_BRNG_MT19937, VSL_BRNG_SFMT19937 and VSL_BRNG_MT2203 are the same generators with
+mtrand.MT32, mtrand.SFMT and mtrand.MTEngine, and a Stream of this package is initialized
+like vslNewStream() or vslNewStreamEx() of MKL.
+
+	s := mklcompat.NewMT19937(mtrand.NewMT32(), 777) // vslNewStream(&stream, VSL_BRNG_MT19937, 777)
+	u := s.Uniform(0, 1)                            // vdRngUniform(VSL_RNG_METHOD_UNIFORM_STD, stream, 1, &u, 0, 1)
+	n := s.UniformInt(1, 7)                         // viRngUniform(VSL_RNG_METHOD_UNIFORM_STD, stream, 1, &n, 1, 7)
+
+MT2203 is a family of 6024 generators of period 2^2203-1 with different parameters,
+VSL_BRNG_MT2203+i for i = 0 to 6023. MT2203Params() makes the parameter set of a member
+from its entry of the MKL parameter table, which is not included in this package.
+
+MKL was not available to capture values, so the streams are tested against the generators of mtrand
+with the initialization above, not against numbers generated by MKL. The seeding rule, init_by_array()
+with the seeds as the key and a seed 1 for no seed, is not verified with MKL,
+and neither is MT2203Params() with an entry of the MKL table.
+*/
+package mklcompat
+
+import (
+	"math"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+)
+
+// MT2203Params() returns the parameter set of an MT2203 generator,
+// with the twist matrix a and the tempering masks b and c of the generator.
+// The 6024 entries of MKL are not included, and the layout of the set is not checked with MKL's table.
+func MT2203Params(a, b, c uint32) mtrand.MTParams {
+	return mtrand.MTParams{
+		W: 32, N: 69, M: 34, R: 5, A: uint64(a),
+		U: 12, D: 0xFFFF_FFFF, S: 7, B: uint64(b), T: 15, C: uint64(c), L: 18,
+		F: 1812433253,
+	}
+}
+
+// Stream is a random stream of MKL on a basic generator
+type Stream struct {
+	next func() uint32
+}
+
+// key of the initialization; no seed is the same with a seed 1
+func seedKey(seeds []uint32) []uint32 {
+	if len(seeds) == 0 {
+		return []uint32{1}
+	}
+	return seeds
+}
+
+// NewMT19937() initializes mt as VSL_BRNG_MT19937 with the seeds, by init_by_array()
+func NewMT19937(mt *mtrand.MT32, seeds ...uint32) *Stream {
+	mt.InitByArray(seedKey(seeds))
+	return &Stream{next: mt.GenUint32}
+}
+
+// NewSFMT19937() initializes s as VSL_BRNG_SFMT19937 with the seeds, by init_by_array()
+func NewSFMT19937(s *mtrand.SFMT, seeds ...uint32) *Stream {
+	s.InitByArray(seedKey(seeds))
+	return &Stream{next: s.GenUint32}
+}
+
+// NewMT2203() initializes mt as a member of VSL_BRNG_MT2203 with the seeds, by init_by_array().
+// The parameters of mt must be made by MT2203Params().
+func NewMT2203(mt *mtrand.MTEngine, seeds ...uint32) *Stream {
+	p := mt.Params()
+	if p.W != 32 || p.N != 69 || p.M != 34 || p.R != 5 {
+		panic("mklcompat: the engine is not MT2203")
+	}
+	key := seedKey(seeds)
+	k64 := make([]uint64, len(key))
+	for i, v := range key {
+		k64[i] = uint64(v)
+	}
+	mt.InitByArray(k64)
+	return &Stream{next: func() uint32 { return uint32(mt.GenUint64()) }}
+}
+
+// UniformBits32() is viRngUniformBits32(); the output of the generator as is
+func (s *Stream) UniformBits32() uint32 {
+	return s.next()
+}
+
+// UniformBits32N() is viRngUniformBits32() for n numbers
+func (s *Stream) UniformBits32N(n int) []uint32 {
+	out := make([]uint32, n)
+	for i := range out {
+		out[i] = s.next()
+	}
+	return out
+}
+
+// Uniform() is vdRngUniform() with VSL_RNG_METHOD_UNIFORM_STD; a float on [a, b)
+func (s *Stream) Uniform(a, b float64) float64 {
+	u := float64(s.next()) * (1.0 / 4294967296.0)
+	return a + float64((b-a)*u)
+}
+
+// UniformN() is vdRngUniform() for n numbers
+func (s *Stream) UniformN(n int, a, b float64) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = s.Uniform(a, b)
+	}
+	return out
+}
+
+// UniformInt() is viRngUniform() with VSL_RNG_METHOD_UNIFORM_STD; an integer on [a, b)
+func (s *Stream) UniformInt(a, b int32) int32 {
+	if a >= b {
+		panic("mklcompat: a >= b")
+	}
+	return int32(math.Floor(s.Uniform(float64(a), float64(b))))
+}
@@ -0,0 +1,58 @@
+package mklcompat_test
+
+import (
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/mklcompat"
+)
+
+// streams are the generators initialized by init_by_array()
+func TestNewStream(t *testing.T) {
+	mt := mtrand.NewMT32()
+	mt.InitByArray([]uint32{777})
+	s := mklcompat.NewMT19937(mtrand.NewMT32(), 777)
+	for i := 0; i < 1000; i++ {
+		if v, r := mt.GenUint32(), s.UniformBits32(); r != v {
+			t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+	}
+
+	// no seed is a seed 1
+	sf := mtrand.NewSFMT()
+	sf.InitByArray([]uint32{1})
+	s = mklcompat.NewSFMT19937(mtrand.NewSFMT())
+	for i := 0; i < 1000; i++ {
+		if v, r := sf.GenUint32(), s.UniformBits32(); r != v {
+			t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+	}
+
+	p := mklcompat.MT2203Params(0xb2a1_2a56, 0x7a3f_6a80, 0xfdf8_0000)
+	e := mtrand.NewMTEngine(p)
+	e.InitByArray([]uint64{1, 2, 3})
+	s = mklcompat.NewMT2203(mtrand.NewMTEngine(p), 1, 2, 3)
+	for i := 0; i < 1000; i++ {
+		if v, r := uint32(e.GenUint64()), s.UniformBits32(); r != v {
+			t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+	}
+}
+
+// uniform numbers are the outputs divided by 2^32
+func TestUniform(t *testing.T) {
+	mt := mtrand.NewMT32()
+	mt.InitByArray([]uint32{1})
+	s := mklcompat.NewMT19937(mtrand.NewMT32(), 1)
+	for i := 0; i < 1000; i++ {
+		v := 2 + 3*mt.GenReal2()
+		if r := s.Uniform(2, 5); r != v {
+			t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, v, r)
+		}
+	}
+	for i := 0; i < 10000; i++ {
+		if r := s.UniformInt(-3, 4); r < -3 || r >= 4 {
+			t.Fatalf("out of range for iteration %d: %v", i, r)
+		}
+	}
+}
@@ -72,6 +72,8 @@ var (
 type MT32 struct {
 	mt []uint32 // the array for the state vector
 	i  int      // index. if i==mtN+1, then mt[] is not initialized
+
+	policy SeedPolicy // the policy of Seed()
 }
 
 // New() creates a new 32-bit Mersenne Twister random generator
@@ -123,6 +125,22 @@ func (mt *MT32) InitByArray(key []uint32) {
 	mt.mt[0] = 0x8000_0000 // MSB is 1; assuring non-zero initial array
 }
 
+// init the state with the given words and the index of the next word, like mt[] and mti of the original.
+// The index is 624 right after Init(), and a new block of words is generated before the next output.
+func (mt *MT32) InitByState(state []uint32, i int) {
+	if len(state) != mt32N || i < 0 || i > mt32N {
+		panic("mtrand: invalid state")
+	}
+	copy(mt.mt, state)
+	mt.i = i
+}
+
+// State() returns a copy of the state words and the index of the next word.
+// The index is 625 if the generator has not been initialized.
+func (mt *MT32) State() (state []uint32, i int) {
+	return append([]uint32(nil), mt.mt...), mt.i
+}
+
 // generates a random number on [0,0xffffffff]-interval
 func (mt *MT32) GenUint32() uint32 {
 	var y uint32
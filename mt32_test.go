@@ -132,3 +132,22 @@ func TestMT32Interface(t *testing.T) {
 	}
 
 }
+
+// a generator restored from a saved state continues the sequence
+func TestMT32State(t *testing.T) {
+	mt := mtrand.NewMT32()
+	mt.Init(1)
+	for i := 0; i < 700; i++ {
+		mt.GenUint32()
+	}
+	state, idx := mt.State()
+
+	mt2 := mtrand.NewMT32()
+	mt2.InitByState(state, idx)
+	for i := 0; i < 1000; i++ {
+		v, r := mt.GenUint32(), mt2.GenUint32()
+		if r != v {
+			t.Errorf("invalid value for iteration %d: expected %x, actual %x", i, v, r)
+		}
+	}
+}
@@ -84,6 +84,8 @@ var (
 type MT64 struct {
 	mt []uint64 // the array for the state vector
 	i  int      // index. if i==mt64NN+1, then mt[] is not initialized
+
+	policy SeedPolicy // the policy of Seed()
 }
 
 // New() creates a new 32-bit Mersenne Twister random generator
@@ -136,6 +138,22 @@ func (mt *MT64) InitByArray(init_key []uint64) {
 	mt.mt[0] = 1 << 63 // MSB is 1; assuring non-zero initial array
 }
 
+// init the state with the given words and the index of the next word, like mt[] and mti of the original.
+// The index is 312 right after Init(), and a new block of words is generated before the next output.
+func (mt *MT64) InitByState(state []uint64, i int) {
+	if len(state) != mt64NN || i < 0 || i > mt64NN {
+		panic("mtrand: invalid state")
+	}
+	copy(mt.mt, state)
+	mt.i = i
+}
+
+// State() returns a copy of the state words and the index of the next word.
+// The index is 313 if the generator has not been initialized.
+func (mt *MT64) State() (state []uint64, i int) {
+	return append([]uint64(nil), mt.mt...), mt.i
+}
+
 // generates a random number on [0, 2^64-1]-interval
 func (mt *MT64) GenUint64() uint64 {
 	var i int
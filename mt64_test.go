@@ -134,3 +134,22 @@ func TestMT64Interface(t *testing.T) {
 	}
 
 }
+
+// a generator restored from a saved state continues the sequence
+func TestMT64State(t *testing.T) {
+	mt := mtrand.NewMT64()
+	mt.Init(1)
+	for i := 0; i < 400; i++ {
+		mt.GenUint64()
+	}
+	state, idx := mt.State()
+
+	mt2 := mtrand.NewMT64()
+	mt2.InitByState(state, idx)
+	for i := 0; i < 1000; i++ {
+		v, r := mt.GenUint64(), mt2.GenUint64()
+		if r != v {
+			t.Errorf("invalid value for iteration %d: expected %x, actual %x", i, v, r)
+		}
+	}
+}
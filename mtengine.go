@@ -0,0 +1,202 @@
+/*
+	mtengine.go
+	a generic Mersenne Twister engine with arbitrary parameters,
+	like C++ std::mersenne_twister_engine
+
+	See M. Matsumoto and T. Nishimura,
+	``Mersenne Twister: a 623-dimensionally equidistributed uniform pseudorandom number generator''
+	ACM Transactions on Modeling and Computer Simulation 8. (Jan. 1998) 3--30.
+	for the meaning of the parameters.
+*/
+
+package mtrand
+
+// Parameter set of a Mersenne Twister engine.
+// Names of the members are the same with template parameters of C++ std::mersenne_twister_engine.
+type MTParams struct {
+	W uint   // word size in bits; 2 to 64
+	N int    // degree of recurrence; number of words in the state vector
+	M int    // middle word offset, 1 <= M <= N
+	R uint   // separation point of a word; number of bits in the lower mask, R < W
+	A uint64 // coefficients of the rational normal form twist matrix; W bits
+	U uint   // tempering shift
+	D uint64 // tempering mask
+	S uint   // tempering shift
+	B uint64 // tempering mask
+	T uint   // tempering shift
+	C uint64 // tempering mask
+	L uint   // tempering shift
+	F uint64 // multiplier for initialization
+}
+
+// Published parameter sets.
+// MT11213A and MT11213B are from Table 2 of the original Matsumoto-Nishimura paper.
+//
+// MT44497, MT86243, MT132049 and MT216091 are not provided. The exponents are those of SFMT,
+// whose parameter sets are of a different recurrence and do not apply to this engine,
+// and this package has no verified source of MT parameter sets for them.
+// A parameter set made by the Dynamic Creator (dcmt) for such an exponent can be given to NewMTEngine() directly.
+var (
+	// MT11213A, period 2^11213-1
+	MT11213A = MTParams{
+		W: 32, N: 351, M: 175, R: 19, A: 0xE4BD_75F5,
+		U: 11, D: 0xFFFF_FFFF, S: 7, B: 0x655E_5280, T: 15, C: 0xFFD5_8000, L: 17,
+		F: 1812433253,
+	}
+
+	// MT11213B, period 2^11213-1 (boost::random::mt11213b)
+	MT11213B = MTParams{
+		W: 32, N: 351, M: 175, R: 19, A: 0xCCAB_8EE7,
+		U: 11, D: 0xFFFF_FFFF, S: 7, B: 0x31B6_AB00, T: 15, C: 0xFFE5_0000, L: 17,
+		F: 1812433253,
+	}
+
+	// MT19937, period 2^19937-1; the parameter set of MT32 and std::mt19937
+	MT19937 = MTParams{
+		W: 32, N: mt32N, M: mt32M, R: 31, A: mt32MatrixA,
+		U: 11, D: 0xFFFF_FFFF, S: 7, B: 0x9D2C_5680, T: 15, C: 0xEFC6_0000, L: 18,
+		F: 1812433253,
+	}
+
+	// MT19937-64, period 2^19937-1; the parameter set of MT64 and std::mt19937_64
+	MT19937_64 = MTParams{
+		W: 64, N: mt64NN, M: mt64MM, R: 31, A: mt64MatrixA,
+		U: 29, D: 0x5555_5555_5555_5555, S: 17, B: 0x71D6_7FFF_EDA6_0000, T: 37, C: 0xFFF7_EEE0_0000_0000, L: 43,
+		F: 6364136223846793005,
+	}
+)
+
+// Mersenne Twister random generator with arbitrary parameters
+type MTEngine struct {
+	p     MTParams
+	wmask uint64   // mask for W bits
+	upper uint64   // upper W-R bits
+	lower uint64   // lower R bits
+	mt    []uint64 // the array for the state vector
+	i     int      // index. if i==N+1, then mt[] is not initialized
+}
+
+// NewMTEngine() creates a new Mersenne Twister random generator with given parameters.
+// It panics if the parameters are out of range.
+func NewMTEngine(p MTParams) *MTEngine {
+	if p.W < 2 || p.W > 64 || p.R >= p.W || p.N < 2 || p.M < 1 || p.M > p.N ||
+		p.U > p.W || p.S > p.W || p.T > p.W || p.L > p.W {
+		panic("mtrand: invalid Mersenne Twister parameters")
+	}
+	wmask := ^uint64(0) >> (64 - p.W)
+	if p.A&^wmask != 0 {
+		panic("mtrand: invalid Mersenne Twister parameters")
+	}
+	lower := uint64(1)<<p.R - 1
+	mt := &MTEngine{
+		p:     p,
+		wmask: wmask,
+		upper: wmask &^ lower,
+		lower: lower,
+		mt:    make([]uint64, p.N),
+		i:     p.N + 1,
+	}
+	return mt
+}
+
+// Params() returns the parameter set of the engine
+func (mt *MTEngine) Params() MTParams {
+	return mt.p
+}
+
+// init mt[N] with a seed; the seed is truncated to W bits
+func (mt *MTEngine) Init(seed uint64) {
+	w2 := mt.p.W - 2
+	mt.mt[0] = seed & mt.wmask
+	for mt.i = 1; mt.i < mt.p.N; mt.i++ {
+		mt.mt[mt.i] = (mt.p.F*(mt.mt[mt.i-1]^(mt.mt[mt.i-1]>>w2)) + uint64(mt.i)) & mt.wmask
+	}
+}
+
+// init with an array, in the way of init_by_array() of "mt19937ar.c" or init_by_array64() of "mt19937-64.c".
+// Only engines of 32-bit or 64-bit words are supported; it panics for other word sizes.
+// Each key value is truncated to W bits.
+func (mt *MTEngine) InitByArray(key []uint64) {
+	var mul1, mul2 uint64
+	switch mt.p.W {
+	case 32:
+		mul1, mul2 = 1664525, 1566083941
+	case 64:
+		mul1, mul2 = 3935559000370003845, 2862933555777941757
+	default:
+		panic("mtrand: InitByArray() requires 32-bit or 64-bit words")
+	}
+	w2 := mt.p.W - 2
+	n := uint64(mt.p.N)
+
+	mt.Init(19650218)
+
+	keylen := uint64(len(key))
+
+	k := len(key)
+	if mt.p.N > k {
+		k = mt.p.N
+	}
+
+	var i, j uint64 = 1, 0
+	for ; k > 0; k-- {
+		mt.mt[i] = ((mt.mt[i] ^ ((mt.mt[i-1] ^ (mt.mt[i-1] >> w2)) * mul1)) + (key[j] & mt.wmask) + j) & mt.wmask // non linear
+		i++
+		j++
+		if i >= n {
+			mt.mt[0] = mt.mt[n-1]
+			i = 1
+		}
+		if j >= keylen {
+			j = 0
+		}
+	}
+	for k = mt.p.N - 1; k > 0; k-- {
+		mt.mt[i] = ((mt.mt[i] ^ ((mt.mt[i-1] ^ (mt.mt[i-1] >> w2)) * mul2)) - i) & mt.wmask // non linear
+		i++
+		if i >= n {
+			mt.mt[0] = mt.mt[n-1]
+			i = 1
+		}
+	}
+	mt.mt[0] = 1 << (mt.p.W - 1) // MSB is 1; assuring non-zero initial array
+}
+
+// generates a random number on [0, 2^W-1]-interval
+func (mt *MTEngine) GenUint64() uint64 {
+	var y uint64
+	n, m := mt.p.N, mt.p.M
+
+	if mt.i >= n { // generate N words at one time
+		var kk int
+
+		if mt.i == n+1 { // if Init() has not been called,
+			mt.Init(5489) // a default initial seed is used
+		}
+
+		mag01 := [2]uint64{0, mt.p.A}
+		for kk = 0; kk < n-m; kk++ {
+			y = (mt.mt[kk] & mt.upper) | (mt.mt[kk+1] & mt.lower)
+			mt.mt[kk] = mt.mt[kk+m] ^ (y >> 1) ^ mag01[y&1]
+		}
+		for ; kk < n-1; kk++ {
+			y = (mt.mt[kk] & mt.upper) | (mt.mt[kk+1] & mt.lower)
+			mt.mt[kk] = mt.mt[kk+(m-n)] ^ (y >> 1) ^ mag01[y&1]
+		}
+		y = (mt.mt[n-1] & mt.upper) | (mt.mt[0] & mt.lower)
+		mt.mt[n-1] = mt.mt[m-1] ^ (y >> 1) ^ mag01[y&1]
+
+		mt.i = 0
+	}
+
+	y = mt.mt[mt.i]
+	mt.i++
+
+	// Tempering
+	y ^= (y >> mt.p.U) & mt.p.D
+	y ^= (y << mt.p.S) & mt.p.B
+	y ^= (y << mt.p.T) & mt.p.C
+	y ^= (y >> mt.p.L)
+
+	return y & mt.wmask
+}
@@ -0,0 +1,74 @@
+package mtrand_test
+
+import (
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+)
+
+// compare the generic engine with MT32 and MT64
+func TestMTEngine(t *testing.T) {
+
+	// MT19937 with InitByArray
+	key32 := []uint32{0x123, 0x234, 0x345, 0x456}
+	mt32 := mtrand.NewMT32()
+	mt32.InitByArray(key32)
+	e32 := mtrand.NewMTEngine(mtrand.MT19937)
+	e32.InitByArray([]uint64{0x123, 0x234, 0x345, 0x456})
+	for i := 0; i < 2000; i++ {
+		v, r := uint64(mt32.GenUint32()), e32.GenUint64()
+		if r != v {
+			t.Fatalf("MT19937 mismatch at iteration %d: expected %x, actual %x", i, v, r)
+		}
+	}
+
+	// MT19937-64 with Init
+	mt64 := mtrand.NewMT64()
+	mt64.Init(12345)
+	e64 := mtrand.NewMTEngine(mtrand.MT19937_64)
+	e64.Init(12345)
+	for i := 0; i < 2000; i++ {
+		v, r := mt64.GenUint64(), e64.GenUint64()
+		if r != v {
+			t.Fatalf("MT19937-64 mismatch at iteration %d: expected %x, actual %x", i, v, r)
+		}
+	}
+
+	// the 10000th output with the default seed, as in C++ standard and Boost.Random
+	target := []struct {
+		name string
+		p    mtrand.MTParams
+		v    uint64
+	}{
+		{"MT11213B", mtrand.MT11213B, 3809585648},
+		{"MT19937", mtrand.MT19937, 4123659995},
+		{"MT19937-64", mtrand.MT19937_64, 9981545732273789042},
+	}
+	for _, c := range target {
+		e := mtrand.NewMTEngine(c.p)
+		var r uint64
+		for i := 0; i < 10000; i++ {
+			r = e.GenUint64()
+		}
+		if r != c.v {
+			t.Errorf("%s: invalid 10000th value: expected %d, actual %d", c.name, c.v, r)
+		}
+	}
+}
+
+func TestMTEngineInvalid(t *testing.T) {
+	small := mtrand.MT11213A
+	small.W, small.R = 1, 0
+	wide := mtrand.MT19937
+	wide.A = 1 << 32
+	for _, p := range []mtrand.MTParams{small, wide} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("invalid parameters are accepted: %+v", p)
+				}
+			}()
+			mtrand.NewMTEngine(p)
+		}()
+	}
+}
@@ -0,0 +1,179 @@
+/*
+	mtjump.go
+	jump-ahead of MT32 and MT64 by polynomial arithmetic,
+	in the way of "Efficient Jump Ahead for F2-Linear Random Number Generators"
+	by Haramoto, Matsumoto, Nishimura, Panneton and L'Ecuyer
+
+	The characteristic polynomial is computed once from the generator itself, by Berlekamp-Massey algorithm,
+	as SFMT-jump of this package.
+	The state array of 624 (or 312) words has 31 bits more than the degree 19937, which are the lower bits of
+	the oldest word and do not affect the following words. A jump of J steps is therefore one step of the recurrence,
+	which drops them, followed by (x^(J-1) mod the characteristic polynomial) of the recurrence.
+*/
+
+package mtrand
+
+import (
+	"math/big"
+	"sync"
+)
+
+var (
+	mt32CharPolyOnce sync.Once
+	mt32CharPoly     f2poly // the characteristic polynomial of the recurrence of MT32
+	mt32CharDegree   int    // degree of mt32CharPoly
+	mt32CharTable    *f2modTable
+
+	mt64CharPolyOnce sync.Once
+	mt64CharPoly     f2poly
+	mt64CharDegree   int
+	mt64CharTable    *f2modTable
+
+	mtJumpCache sync.Map
+)
+
+// mt32NextState() generates the word of the recurrence at position p, replacing the oldest word at p
+func mt32NextState(mt []uint32, p int) {
+	y := (mt[p] & mt32UpperMask) | (mt[(p+1)%mt32N] & mt32LowerMask)
+	mt[p] = mt[(p+mt32M)%mt32N] ^ (y >> 1) ^ mag01[y&1]
+}
+
+// mt64NextState() generates the word of the recurrence at position p, replacing the oldest word at p
+func mt64NextState(mt []uint64, p int) {
+	x := (mt[p] & mt64UM) | (mt[(p+1)%mt64NN] & mt64LM)
+	mt[p] = mt[(p+mt64MM)%mt64NN] ^ (x >> 1) ^ mt64mag01[x&1]
+}
+
+// characteristic polynomial from the lowest bits of the words of the recurrence
+func mtCalcCharPoly(n int, next func() uint64) (f2poly, int) {
+	seq := newF2poly(n)
+	for i := 0; i < n; i++ {
+		if next()&1 != 0 {
+			seq.setBit(i)
+		}
+	}
+	c, l := berlekampMasseyPacked(seq, n)
+	// the characteristic polynomial is the reciprocal of the connection polynomial
+	p := newF2poly(l)
+	for i := 0; i <= l; i++ {
+		if c.bit(i) != 0 {
+			p.setBit(l - i)
+		}
+	}
+	return p, l
+}
+
+func mt32CalcCharPoly() {
+	mt := NewMT32()
+	mt.Init(5489)
+	p := 0
+	mt32CharPoly, mt32CharDegree = mtCalcCharPoly(2*mt32N*32, func() uint64 {
+		mt32NextState(mt.mt, p)
+		v := mt.mt[p]
+		p = (p + 1) % mt32N
+		return uint64(v)
+	})
+	mt32CharTable = newF2modTable(mt32CharPoly, mt32CharDegree)
+}
+
+func mt64CalcCharPoly() {
+	mt := NewMT64()
+	mt.Init(5489)
+	p := 0
+	mt64CharPoly, mt64CharDegree = mtCalcCharPoly(2*mt64NN*64, func() uint64 {
+		mt64NextState(mt.mt, p)
+		v := mt.mt[p]
+		p = (p + 1) % mt64NN
+		return v
+	})
+	mt64CharTable = newF2modTable(mt64CharPoly, mt64CharDegree)
+}
+
+// mtJumpPoly() returns x^(mulStep * 2^baseStep - 1) mod the modulus of t. Results are cached.
+func mtJumpPoly(w int, t *f2modTable, mulStep uint64, baseStep uint) f2poly {
+	type key struct {
+		w    int
+		mul  uint64
+		base uint
+	}
+	k := key{w, mulStep, baseStep}
+	if v, ok := mtJumpCache.Load(k); ok {
+		return v.(f2poly)
+	}
+
+	e := new(big.Int).Lsh(new(big.Int).SetUint64(mulStep), baseStep)
+	e.Sub(e, big.NewInt(1))
+	r := newF2poly(t.d)
+	r[0] = 1
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		r = t.squareMod(r)
+		if e.Bit(i) != 0 {
+			r.mulXMod(t.m, t.d)
+		}
+	}
+
+	mtJumpCache.Store(k, r)
+	return r
+}
+
+// mt32.Jump() advances the state by (mulStep * 2^baseStep) numbers of GenUint32()
+func (mt *MT32) Jump(mulStep uint64, baseStep uint) {
+	if mulStep == 0 {
+		return
+	}
+	if mt.i == mt32N+1 {
+		mt.Init(5489)
+	}
+	mt32CharPolyOnce.Do(mt32CalcCharPoly)
+	jp := mtJumpPoly(32, mt32CharTable, mulStep, baseStep)
+
+	s := append([]uint32(nil), mt.mt...)
+	mt32NextState(s, 0)
+	p := 1
+	work := make([]uint32, mt32N) // the oldest word at 0
+	for i := 0; i < mt32CharDegree; i++ {
+		if jp.bit(i) != 0 {
+			j := 0
+			for ; j < mt32N-p; j++ {
+				work[j] ^= s[p+j]
+			}
+			for ; j < mt32N; j++ {
+				work[j] ^= s[p+j-mt32N]
+			}
+		}
+		mt32NextState(s, p)
+		p = (p + 1) % mt32N
+	}
+	copy(mt.mt, work)
+}
+
+// mt64.Jump() advances the state by (mulStep * 2^baseStep) numbers of GenUint64()
+func (mt *MT64) Jump(mulStep uint64, baseStep uint) {
+	if mulStep == 0 {
+		return
+	}
+	if mt.i == mt64NN+1 {
+		mt.Init(5489)
+	}
+	mt64CharPolyOnce.Do(mt64CalcCharPoly)
+	jp := mtJumpPoly(64, mt64CharTable, mulStep, baseStep)
+
+	s := append([]uint64(nil), mt.mt...)
+	mt64NextState(s, 0)
+	p := 1
+	work := make([]uint64, mt64NN)
+	for i := 0; i < mt64CharDegree; i++ {
+		if jp.bit(i) != 0 {
+			j := 0
+			for ; j < mt64NN-p; j++ {
+				work[j] ^= s[p+j]
+			}
+			for ; j < mt64NN; j++ {
+				work[j] ^= s[p+j-mt64NN]
+			}
+		}
+		mt64NextState(s, p)
+		p = (p + 1) % mt64NN
+	}
+	copy(mt.mt, work)
+}
@@ -0,0 +1,66 @@
+package mtrand_test
+
+import (
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+)
+
+// jumping must be the same as generating numbers
+func TestMTJump(t *testing.T) {
+	steps := []struct {
+		mul  uint64
+		base uint
+		skip int // numbers taken before jump
+	}{
+		{1, 0, 0}, {1, 0, 8}, {311, 0, 0}, {313, 0, 4}, {624, 0, 0}, {10000, 0, 100}, {3, 10, 0}, {1, 14, 620},
+	}
+	for _, c := range steps {
+		m1, m2 := mtrand.NewMT32(), mtrand.NewMT32()
+		m1.Init(1234)
+		m2.Init(1234)
+		for i := 0; i < c.skip; i++ {
+			m1.GenUint32()
+			m2.GenUint32()
+		}
+		for i := 0; i < int(c.mul)<<c.base; i++ {
+			m1.GenUint32()
+		}
+		m2.Jump(c.mul, c.base)
+		for i := 0; i < 1000; i++ {
+			if v, r := m1.GenUint32(), m2.GenUint32(); r != v {
+				t.Errorf("MT32 jump %d*2^%d: invalid value for iteration %d: expected %d, actual %d", c.mul, c.base, i, v, r)
+				break
+			}
+		}
+
+		n1, n2 := mtrand.NewMT64(), mtrand.NewMT64()
+		n1.InitByArray([]uint64{0x12345, 0x23456, 0x34567, 0x45678})
+		n2.InitByArray([]uint64{0x12345, 0x23456, 0x34567, 0x45678})
+		for i := 0; i < c.skip; i++ {
+			n1.GenUint64()
+			n2.GenUint64()
+		}
+		for i := 0; i < int(c.mul)<<c.base; i++ {
+			n1.GenUint64()
+		}
+		n2.Jump(c.mul, c.base)
+		for i := 0; i < 1000; i++ {
+			if v, r := n1.GenUint64(), n2.GenUint64(); r != v {
+				t.Errorf("MT64 jump %d*2^%d: invalid value for iteration %d: expected %d, actual %d", c.mul, c.base, i, v, r)
+				break
+			}
+		}
+	}
+
+	// a jump is the sum of its parts
+	m1, m2 := mtrand.NewMT64(), mtrand.NewMT64()
+	m1.Jump(1, 64)
+	m1.Jump(1, 64)
+	m2.Jump(1, 65)
+	for i := 0; i < 100; i++ {
+		if v, r := m1.GenUint64(), m2.GenUint64(); r != v {
+			t.Fatalf("invalid value for iteration %d: expected %d, actual %d", i, v, r)
+		}
+	}
+}
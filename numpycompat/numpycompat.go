@@ -0,0 +1,354 @@
+/*
+Package numpycompat reproduces NumPy's legacy numpy.random.RandomState on a Mersenne Twister of mtrand.
+
+RandomState is MT19937, the same generator with mtrand.MT32,
+and RandomState in this package generates the same numbers with NumPy for the same seed.
+
+	rs := numpycompat.New(mtrand.NewMT32())
+	rs.Seed(0)                  // rs = np.random.RandomState(0)
+	x := rs.RandomSample()      // rs.random_sample()
+	n := rs.RandIntN(0, 10, 5)  // rs.randint(0, 10, 5)
+
+Like math/rand, invalid arguments cause a panic, where NumPy raises ValueError.
+*/
+package numpycompat
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/internal/libm"
+)
+
+// RandomState is a random number generator with the algorithms of numpy.random.RandomState
+type RandomState struct {
+	mt       *mtrand.MT32
+	gauss    float64 // saved number of StandardNormal()
+	hasGauss bool    // true if gauss is valid
+}
+
+// New() creates a RandomState on mt. The state of mt is used as is until Seed() or SeedArray() is called.
+func New(mt *mtrand.MT32) *RandomState {
+	return &RandomState{mt: mt}
+}
+
+// MT32() returns the underlying generator
+func (rs *RandomState) MT32() *mtrand.MT32 {
+	return rs.mt
+}
+
+//
+// seeding
+//
+
+// Seed() is RandomState.seed(seed) for an integer seed
+func (rs *RandomState) Seed(seed uint32) {
+	rs.mt.Init(seed)
+	rs.gauss, rs.hasGauss = 0, false
+}
+
+// SeedArray() is RandomState.seed(seed) for an array of integers
+func (rs *RandomState) SeedArray(seed []uint32) {
+	if len(seed) == 0 {
+		panic("numpycompat: seed must be non-empty")
+	}
+	rs.mt.InitByArray(seed)
+	rs.gauss, rs.hasGauss = 0, false
+}
+
+//
+// integers
+//
+
+// mask of all bits below the highest bit of v
+func genMask(v uint64) uint64 {
+	return ^uint64(0) >> bits.LeadingZeros64(v|1)
+}
+
+// 64-bit number from two 32-bit numbers; the first one is the upper half
+func (rs *RandomState) next64() uint64 {
+	hi := uint64(rs.mt.GenUint32())
+	return hi<<32 | uint64(rs.mt.GenUint32())
+}
+
+// integer on [0, rng] by masked rejection
+func (rs *RandomState) boundedMasked(rng uint64) uint64 {
+	switch {
+	case rng == 0:
+		return 0
+	case rng < 0xffff_ffff:
+		mask := uint32(genMask(rng))
+		for {
+			if v := rs.mt.GenUint32() & mask; uint64(v) <= rng {
+				return uint64(v)
+			}
+		}
+	case rng == 0xffff_ffff:
+		return uint64(rs.mt.GenUint32())
+	case rng == ^uint64(0):
+		return rs.next64()
+	}
+	mask := genMask(rng)
+	for {
+		if v := rs.next64() & mask; v <= rng {
+			return v
+		}
+	}
+}
+
+// RandInt() is RandomState.randint(low, high); an integer on [low, high)
+func (rs *RandomState) RandInt(low, high int64) int64 {
+	if low >= high {
+		panic("numpycompat: low >= high")
+	}
+	return low + int64(rs.boundedMasked(uint64(high-1)-uint64(low)))
+}
+
+// RandIntN() is RandomState.randint(low, high, size)
+func (rs *RandomState) RandIntN(low, high int64, size int) []int64 {
+	if size == 0 {
+		return []int64{} // NumPy returns an empty array before checking the bounds
+	}
+	if low >= high {
+		panic("numpycompat: low >= high")
+	}
+	rng := uint64(high-1) - uint64(low)
+	out := make([]int64, size)
+	for i := range out {
+		out[i] = low + int64(rs.boundedMasked(rng))
+	}
+	return out
+}
+
+// random_interval() of NumPy; an integer on [0, max]
+func (rs *RandomState) interval(max uint64) uint64 {
+	if max == 0 {
+		return 0
+	}
+	mask := genMask(max)
+	if max <= 0xffff_ffff {
+		for {
+			if v := uint64(rs.mt.GenUint32()) & mask; v <= max {
+				return v
+			}
+		}
+	}
+	for {
+		if v := rs.next64() & mask; v <= max {
+			return v
+		}
+	}
+}
+
+//
+// floats
+//
+
+// RandomSample() is RandomState.random_sample(); a float on [0, 1) with 53-bit resolution
+func (rs *RandomState) RandomSample() float64 {
+	return rs.mt.GenRes53()
+}
+
+// RandomSampleN() is RandomState.random_sample(size)
+func (rs *RandomState) RandomSampleN(size int) []float64 {
+	out := make([]float64, size)
+	for i := range out {
+		out[i] = rs.mt.GenRes53()
+	}
+	return out
+}
+
+// StandardNormal() is RandomState.standard_normal(), by Marsaglia polar method.
+// The second number of a pair is kept for the next call, as NumPy does.
+func (rs *RandomState) StandardNormal() float64 {
+	if rs.hasGauss {
+		rs.hasGauss = false
+		v := rs.gauss
+		rs.gauss = 0
+		return v
+	}
+	var x1, x2, r2 float64
+	for {
+		x1 = 2.0*rs.RandomSample() - 1.0
+		x2 = 2.0*rs.RandomSample() - 1.0
+		r2 = float64(x1*x1) + float64(x2*x2)
+		if r2 < 1.0 && r2 != 0.0 {
+			break
+		}
+	}
+	f := math.Sqrt(-2.0 * libm.Log(r2) / r2)
+	rs.gauss, rs.hasGauss = f*x1, true
+	return f * x2
+}
+
+// StandardNormalN() is RandomState.standard_normal(size)
+func (rs *RandomState) StandardNormalN(size int) []float64 {
+	out := make([]float64, size)
+	for i := range out {
+		out[i] = rs.StandardNormal()
+	}
+	return out
+}
+
+//
+// sequences
+//
+
+// Shuffle() is RandomState.shuffle(x) for a 1-d array of length n; swap swaps the elements with indexes i and j
+func (rs *RandomState) Shuffle(n int, swap func(i, j int)) {
+	for i := n - 1; i > 0; i-- {
+		j := int(rs.interval(uint64(i)))
+		swap(i, j)
+	}
+}
+
+// Permutation() is RandomState.permutation(n)
+func (rs *RandomState) Permutation(n int) []int64 {
+	out := make([]int64, n)
+	for i := range out {
+		out[i] = int64(i)
+	}
+	rs.Shuffle(n, func(i, j int) { out[i], out[j] = out[j], out[i] })
+	return out
+}
+
+// cumulative distribution of p, normalized by its last element
+func cdf(p []float64) []float64 {
+	c := make([]float64, len(p))
+	sum := 0.0
+	for i, v := range p {
+		sum += v
+		c[i] = sum
+	}
+	for i := range c {
+		c[i] /= sum
+	}
+	return c
+}
+
+// kahan_sum() of NumPy
+func kahanSum(p []float64) float64 {
+	if len(p) == 0 {
+		return 0
+	}
+	sum, c := p[0], 0.0
+	for _, v := range p[1:] {
+		y := v - c
+		t := sum + y
+		c = (t - sum) - y
+		sum = t
+	}
+	return sum
+}
+
+// checkProbabilities() panics for p that RandomState.choice() rejects
+func checkProbabilities(p []float64) {
+	const atol = 1.4901161193847656e-08 // sqrt(finfo(float64).eps)
+	sum := kahanSum(p)
+	if math.IsNaN(sum) {
+		panic("numpycompat: probabilities contain NaN")
+	}
+	for _, v := range p {
+		if v < 0 {
+			panic("numpycompat: probabilities are not non-negative")
+		}
+	}
+	if math.Abs(sum-1) > atol {
+		panic("numpycompat: probabilities do not sum to 1")
+	}
+}
+
+// searchsorted(cdf, x, side='right')
+func searchRight(cdf []float64, x float64) int64 {
+	return int64(sort.Search(len(cdf), func(i int) bool { return cdf[i] > x }))
+}
+
+// Choice() is RandomState.choice(a, size, replace, p) for an integer a; p may be nil for the uniform distribution
+func (rs *RandomState) Choice(a, size int, replace bool, p []float64) []int64 {
+	if a <= 0 && size != 0 {
+		panic("numpycompat: a must be greater than 0 unless no samples are taken")
+	}
+	if p != nil && len(p) != a {
+		panic("numpycompat: a and p must have same size")
+	}
+	if p != nil {
+		checkProbabilities(p)
+	}
+	if size < 0 {
+		panic("numpycompat: negative dimensions are not allowed")
+	}
+	if a <= 0 {
+		return []int64{} // an empty population, of which no sample is taken
+	}
+
+	if replace {
+		if p == nil {
+			return rs.RandIntN(0, int64(a), size)
+		}
+		c := cdf(p)
+		out := make([]int64, size)
+		for i, u := range rs.RandomSampleN(size) {
+			out[i] = searchRight(c, u)
+		}
+		return out
+	}
+
+	if size > a {
+		panic("numpycompat: cannot take a larger sample than population when 'replace=False'")
+	}
+	if p == nil {
+		return rs.Permutation(a)[:size]
+	}
+
+	nonzero := 0
+	for _, v := range p {
+		if v > 0 {
+			nonzero++
+		}
+	}
+	if nonzero < size {
+		panic("numpycompat: fewer non-zero entries in p than size")
+	}
+	p = append([]float64(nil), p...)
+	found := make([]int64, 0, size)
+	for len(found) < size {
+		x := rs.RandomSampleN(size - len(found))
+		for _, f := range found {
+			p[f] = 0
+		}
+		c := cdf(p)
+		// unique values of the new indices, in the order of their first appearance
+		seen := make(map[int64]bool)
+		for _, u := range x {
+			v := searchRight(c, u)
+			if !seen[v] {
+				seen[v] = true
+				found = append(found, v)
+			}
+		}
+	}
+	return found
+}
+
+//
+// bytes
+//
+
+// Bytes() is RandomState.bytes(length)
+func (rs *RandomState) Bytes(length int) []byte {
+	if length < 0 {
+		panic("numpycompat: negative length")
+	}
+	if length == 0 {
+		return []byte{} // no number is taken; (length-1)/4 + 1 of Go would be 1
+	}
+	n := (length-1)/4 + 1
+	buf := make([]byte, 4*n)
+	for i := 0; i < n; i++ {
+		v := rs.mt.GenUint32()
+		buf[4*i], buf[4*i+1], buf[4*i+2], buf[4*i+3] = byte(v), byte(v>>8), byte(v>>16), byte(v>>24)
+	}
+	return buf[:length]
+}
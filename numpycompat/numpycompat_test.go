@@ -0,0 +1,192 @@
+package numpycompat_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/numpycompat"
+)
+
+// compare with outputs of NumPy
+func TestRandomState(t *testing.T) {
+	rs := numpycompat.New(mtrand.NewMT32())
+
+	// np.random.RandomState(0).random_sample(5)
+	rs.Seed(0)
+	target1 := []float64{0.5488135039273248, 0.7151893663724195, 0.6027633760716439, 0.5448831829968969, 0.4236547993389047}
+	for i, v := range target1 {
+		if x := rs.RandomSample(); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+
+	// np.random.RandomState(42).random_sample(3)
+	rs.Seed(42)
+	target2 := []float64{0.3745401188473625, 0.9507143064099162, 0.7319939418114051}
+	if x := rs.RandomSampleN(3); !reflect.DeepEqual(x, target2) {
+		t.Errorf("invalid random_sample: expected %v, actual %v", target2, x)
+	}
+
+	// np.random.RandomState(0).standard_normal(5)
+	rs.Seed(0)
+	target3 := []float64{1.764052345967664, 0.4001572083672233, 0.9787379841057392, 2.240893199201458, 1.8675579901499675}
+	for i, v := range target3 {
+		if x := rs.StandardNormal(); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+
+	// np.random.RandomState(42).standard_normal(5)
+	rs.Seed(42)
+	target4 := []float64{0.4967141530112327, -0.13826430117118466, 0.6476885381006925, 1.5230298564080254, -0.23415337472333597}
+	if x := rs.StandardNormalN(5); !reflect.DeepEqual(x, target4) {
+		t.Errorf("invalid standard_normal: expected %v, actual %v", target4, x)
+	}
+
+	// np.random.RandomState(0).randint(0, 10, 5)
+	rs.Seed(0)
+	target5 := []int64{5, 0, 3, 3, 7}
+	if x := rs.RandIntN(0, 10, 5); !reflect.DeepEqual(x, target5) {
+		t.Errorf("invalid randint: expected %v, actual %v", target5, x)
+	}
+
+	// np.random.RandomState(42).randint(0, 100, 5)
+	rs.Seed(42)
+	target6 := []int64{51, 92, 14, 71, 60}
+	if x := rs.RandIntN(0, 100, 5); !reflect.DeepEqual(x, target6) {
+		t.Errorf("invalid randint: expected %v, actual %v", target6, x)
+	}
+
+	// np.random.RandomState(0).permutation(10)
+	rs.Seed(0)
+	target7 := []int64{2, 8, 4, 9, 1, 6, 7, 3, 0, 5}
+	if x := rs.Permutation(10); !reflect.DeepEqual(x, target7) {
+		t.Errorf("invalid permutation: expected %v, actual %v", target7, x)
+	}
+}
+
+func TestRandomStateSeeding(t *testing.T) {
+	// an array seed is init_by_array()
+	rs := numpycompat.New(mtrand.NewMT32())
+	rs.SeedArray([]uint32{0x123, 0x234, 0x345, 0x456})
+	mt := mtrand.NewMT32()
+	mt.InitByArray([]uint32{0x123, 0x234, 0x345, 0x456})
+	for i := 0; i < 10; i++ {
+		if x, v := rs.MT32().GenUint32(), mt.GenUint32(); x != v {
+			t.Errorf("invalid value for iteration %d: expected %x, actual %x", i, v, x)
+		}
+	}
+
+	// seeding discards the cached normal
+	rs.Seed(0)
+	x := rs.StandardNormal()
+	rs.Seed(0)
+	if y := rs.StandardNormal(); x != y {
+		t.Errorf("invalid standard_normal after seed: expected %v, actual %v", x, y)
+	}
+}
+
+func TestRandomStateSequences(t *testing.T) {
+	rs := numpycompat.New(mtrand.NewMT32())
+
+	// shuffle is the same with permutation
+	rs.Seed(0)
+	a := []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	rs.Shuffle(len(a), func(i, j int) { a[i], a[j] = a[j], a[i] })
+	rs.Seed(0)
+	if p := rs.Permutation(10); !reflect.DeepEqual(a, p) {
+		t.Errorf("invalid shuffle: expected %v, actual %v", p, a)
+	}
+
+	// choice with replacement is randint
+	rs.Seed(0)
+	c := rs.Choice(10, 5, true, nil)
+	if !reflect.DeepEqual(c, []int64{5, 0, 3, 3, 7}) {
+		t.Errorf("invalid choice: %v", c)
+	}
+
+	// choice without replacement is a head of permutation
+	rs.Seed(0)
+	c = rs.Choice(10, 3, false, nil)
+	if !reflect.DeepEqual(c, []int64{2, 8, 4}) {
+		t.Errorf("invalid choice: %v", c)
+	}
+
+	// weighted choices never pick zero weights, and do not repeat without replacement
+	p := []float64{0.1, 0, 0.3, 0.6, 0}
+	rs.Seed(1)
+	for _, v := range rs.Choice(5, 100, true, p) {
+		if p[v] == 0 {
+			t.Errorf("invalid choice: %d has zero weight", v)
+		}
+	}
+	for i := 0; i < 100; i++ {
+		c = rs.Choice(5, 3, false, p)
+		seen := make(map[int64]bool)
+		for _, v := range c {
+			if p[v] == 0 || seen[v] {
+				t.Errorf("invalid choice without replacement: %v", c)
+			}
+			seen[v] = true
+		}
+	}
+
+	// bytes are little endian 32-bit numbers
+	rs.Seed(0)
+	b := rs.Bytes(6)
+	mt := mtrand.NewMT32()
+	mt.Init(0)
+	v0, v1 := mt.GenUint32(), mt.GenUint32()
+	expected := []byte{byte(v0), byte(v0 >> 8), byte(v0 >> 16), byte(v0 >> 24), byte(v1), byte(v1 >> 8)}
+	if !reflect.DeepEqual(b, expected) {
+		t.Errorf("invalid bytes: expected %x, actual %x", expected, b)
+	}
+
+	// no number is taken for zero bytes
+	rs.Seed(0)
+	if b := rs.Bytes(0); len(b) != 0 {
+		t.Errorf("invalid bytes: %x", b)
+	}
+	mt.Init(0)
+	if v, r := mt.GenUint32(), uint32(rs.RandInt(0, 1<<32)); r != v {
+		t.Errorf("invalid value after Bytes(0): expected %d, actual %d", v, r)
+	}
+
+	// probabilities must be valid
+	for _, p := range [][]float64{{0.5, 0.4}, {0.5, 0.6}, {1.5, -0.5}, {math.NaN(), 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("invalid probabilities are accepted: %v", p)
+				}
+			}()
+			rs.Choice(2, 1, true, p)
+		}()
+	}
+	rs.Choice(3, 1, true, []float64{0.1, 0.2, 0.7 + 1e-9}) // within the tolerance
+
+	// an empty population is accepted when no samples are taken, and no number is drawn
+	mt.Init(1)
+	rs.Seed(1)
+	for _, a := range []int{0, -3} {
+		if c := rs.Choice(a, 0, true, nil); c == nil || len(c) != 0 {
+			t.Errorf("invalid choice from %d: %v", a, c)
+		}
+		if c := rs.Choice(a, 0, false, nil); c == nil || len(c) != 0 {
+			t.Errorf("invalid choice from %d: %v", a, c)
+		}
+	}
+	if v, r := mt.GenUint32(), uint32(rs.RandInt(0, 1<<32)); r != v {
+		t.Errorf("invalid value after Choice(0, 0): expected %d, actual %d", v, r)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("a sample is taken from an empty population")
+			}
+		}()
+		rs.Choice(0, 1, true, nil)
+	}()
+}
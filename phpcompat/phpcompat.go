@@ -0,0 +1,177 @@
+/*
+Package phpcompat reproduces PHP's mt_srand() and mt_rand() on a Mersenne Twister of mtrand.
+
+PHP's mt_rand() is MT19937, the same generator with mtrand.MT32, with its output shifted right by 1.
+Before PHP 7.1, the generator had a modified twist and mt_rand(min, max) scaled a float;
+PHP 7.1 and later reproduce this behaviour with mt_srand($seed, MT_RAND_PHP), which is ModePHP here.
+
+	r := phpcompat.New(mtrand.NewMT32())
+	r.Srand(1234, phpcompat.ModeMT19937) // mt_srand(1234)
+	x := r.Rand()                        // mt_rand()
+	n := r.RandRange(1, 6)               // mt_rand(1, 6)
+
+RandRange() of ModeMT19937 follows PHP 7.1 to 8.1 for ranges wider than 32 bits,
+where the first of two 32-bit outputs is the upper half of a 64-bit number.
+PHP was not available to capture values, so the range algorithms are tested by regression values
+of this package and by their properties, not by numbers generated by PHP.
+Like math/rand, invalid arguments cause a panic, where PHP raises an error.
+*/
+package phpcompat
+
+import (
+	mtrand "github.com/mixcode/golib-mtrand"
+)
+
+// Mode selects the algorithm of mt_srand()
+type Mode int
+
+const (
+	ModeMT19937 Mode = 0 // MT_RAND_MT19937, the correct Mersenne Twister of PHP 7.1 and later
+	ModePHP     Mode = 1 // MT_RAND_PHP, the modified twist and scaling of PHP 5.2.1 to 7.0
+)
+
+// RandMax is the value of mt_getrandmax()
+const RandMax = 0x7fff_ffff
+
+const (
+	n = 624
+	m = 397
+)
+
+// MTRand is a random number generator with the algorithms of PHP's mt_rand()
+type MTRand struct {
+	mt   *mtrand.MT32
+	mode Mode
+
+	// the state of ModePHP, which MT32 cannot generate
+	state []uint32
+	i     int
+}
+
+// New() creates an MTRand on mt in ModeMT19937. The state of mt is used as is until Srand() is called.
+func New(mt *mtrand.MT32) *MTRand {
+	return &MTRand{mt: mt, mode: ModeMT19937}
+}
+
+// MT32() returns the underlying generator of ModeMT19937
+func (r *MTRand) MT32() *mtrand.MT32 {
+	return r.mt
+}
+
+// Mode() returns the current mode
+func (r *MTRand) Mode() Mode {
+	return r.mode
+}
+
+// Srand() is mt_srand(seed, mode). Only the lower 32 bits of seed are used, as PHP does.
+func (r *MTRand) Srand(seed int64, mode Mode) {
+	switch mode {
+	case ModeMT19937:
+		r.mt.Init(uint32(seed))
+		r.state = nil
+	case ModePHP:
+		if r.state == nil {
+			r.state = make([]uint32, n)
+		}
+		st := r.state
+		st[0] = uint32(seed)
+		for i := 1; i < n; i++ {
+			st[i] = 1812433253*(st[i-1]^(st[i-1]>>30)) + uint32(i)
+		}
+		r.i = n
+	default:
+		panic("phpcompat: invalid mode")
+	}
+	r.mode = mode
+}
+
+// twist of MT_RAND_PHP; the matrix is selected by the lowest bit of u, not v
+func twistPHP(x, u, v uint32) uint32 {
+	return x ^ ((u&0x8000_0000)|(v&0x7fff_ffff))>>1 ^ (-(u & 1) & 0x9908_b0df)
+}
+
+// php_mt_rand(); a 32-bit number before the shift
+func (r *MTRand) next() uint32 {
+	if r.mode == ModeMT19937 {
+		return r.mt.GenUint32()
+	}
+	st := r.state
+	if r.i >= n {
+		k := 0
+		for ; k < n-m; k++ {
+			st[k] = twistPHP(st[k+m], st[k], st[k+1])
+		}
+		for ; k < n-1; k++ {
+			st[k] = twistPHP(st[k+m-n], st[k], st[k+1])
+		}
+		st[n-1] = twistPHP(st[m-1], st[n-1], st[0])
+		r.i = 0
+	}
+	y := st[r.i]
+	r.i++
+	y ^= (y >> 11)
+	y ^= (y << 7) & 0x9d2c5680
+	y ^= (y << 15) & 0xefc60000
+	y ^= (y >> 18)
+	return y
+}
+
+// Rand() is mt_rand(); a number on [0, RandMax]
+func (r *MTRand) Rand() int64 {
+	return int64(r.next() >> 1)
+}
+
+// RandRange() is mt_rand(min, max); a number on [min, max]
+func (r *MTRand) RandRange(min, max int64) int64 {
+	if max < min {
+		panic("phpcompat: max must be greater than or equal to min")
+	}
+	if r.mode == ModePHP {
+		// RAND_RANGE_BADSCALING()
+		v := float64(r.next() >> 1)
+		return min + int64((float64(max)-float64(min)+1.0)*(v/(RandMax+1.0)))
+	}
+	umax := uint64(max) - uint64(min)
+	if umax > 0xffff_ffff {
+		return int64(uint64(min) + r.range64(umax))
+	}
+	return int64(uint64(min) + uint64(r.range32(uint32(umax))))
+}
+
+// rand_range32(); a number on [0, umax]
+func (r *MTRand) range32(umax uint32) uint32 {
+	result := r.next()
+	if umax == 0xffff_ffff {
+		return result
+	}
+	umax++
+	if umax&(umax-1) == 0 {
+		return result & (umax - 1)
+	}
+	limit := 0xffff_ffff - (0xffff_ffff % umax) - 1
+	for result > limit {
+		result = r.next()
+	}
+	return result % umax
+}
+
+// rand_range64(); a number on [0, umax]
+func (r *MTRand) range64(umax uint64) uint64 {
+	next64 := func() uint64 {
+		hi := uint64(r.next())
+		return hi<<32 | uint64(r.next())
+	}
+	result := next64()
+	if umax == ^uint64(0) {
+		return result
+	}
+	umax++
+	if umax&(umax-1) == 0 {
+		return result & (umax - 1)
+	}
+	limit := ^uint64(0) - (^uint64(0) % umax) - 1
+	for result > limit {
+		result = next64()
+	}
+	return result % umax
+}
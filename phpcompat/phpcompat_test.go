@@ -0,0 +1,91 @@
+package phpcompat_test
+
+import (
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/phpcompat"
+)
+
+func TestMTRand(t *testing.T) {
+	r := phpcompat.New(mtrand.NewMT32())
+
+	// mt_srand(1); mt_rand() of PHP 7.1 and later
+	r.Srand(1, phpcompat.ModeMT19937)
+	target1 := []int64{895547922, 2141438069, 1546885062}
+	for i, v := range target1 {
+		if x := r.Rand(); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+
+	// mt_srand(1); mt_rand() of PHP 5.2.1 to 7.0, or mt_srand(1, MT_RAND_PHP)
+	r.Srand(1, phpcompat.ModePHP)
+	target2 := []int64{1244335972, 15217923, 1546885062}
+	for i, v := range target2 {
+		if x := r.Rand(); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+
+	// ModeMT19937 is MT19937 shifted by 1, after the first reload too
+	r.Srand(-1, phpcompat.ModeMT19937)
+	mt := mtrand.NewMT32()
+	mt.Init(0xffff_ffff)
+	for i := 0; i < 2000; i++ {
+		if x, v := r.Rand(), int64(mt.GenUint32()>>1); x != v {
+			t.Errorf("invalid value for iteration %d: expected %x, actual %x", i, v, x)
+			break
+		}
+	}
+}
+
+// regression values of the range algorithms
+func TestMTRandRange(t *testing.T) {
+	r := phpcompat.New(mtrand.NewMT32())
+
+	// mt_srand(42); mt_rand(1, 100)
+	r.Srand(42, phpcompat.ModeMT19937)
+	target1 := []int64{43, 68, 77, 15, 27}
+	for i, v := range target1 {
+		if x := r.RandRange(1, 100); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+
+	// mt_srand(42, MT_RAND_PHP); mt_rand(1, 100)
+	r.Srand(42, phpcompat.ModePHP)
+	target2 := []int64{64, 80, 96, 82, 28}
+	for i, v := range target2 {
+		if x := r.RandRange(1, 100); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+
+	// a power of 2 is masked, and the full range is the raw output
+	r.Srand(5, phpcompat.ModeMT19937)
+	mt := mtrand.NewMT32()
+	mt.Init(5)
+	if x, v := r.RandRange(0, 255), int64(mt.GenUint32()&255); x != v {
+		t.Errorf("invalid masked range: expected %v, actual %v", v, x)
+	}
+	if x, v := r.RandRange(-1<<31, 1<<31-1), int64(mt.GenUint32())-1<<31; x != v {
+		t.Errorf("invalid full range: expected %v, actual %v", v, x)
+	}
+
+	// a 64-bit range takes the upper half first
+	hi, lo := uint64(mt.GenUint32()), uint64(mt.GenUint32())
+	if x, v := r.RandRange(0, 1<<40-1), int64((hi<<32|lo)&(1<<40-1)); x != v {
+		t.Errorf("invalid 64-bit range: expected %v, actual %v", v, x)
+	}
+
+	// ranges stay in bounds
+	for _, mode := range []phpcompat.Mode{phpcompat.ModeMT19937, phpcompat.ModePHP} {
+		r.Srand(7, mode)
+		for i := 0; i < 1000; i++ {
+			if x := r.RandRange(-3, 3); x < -3 || x > 3 {
+				t.Errorf("out of range for mode %d: %v", mode, x)
+			}
+		}
+	}
+}
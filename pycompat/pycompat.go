@@ -0,0 +1,305 @@
+/*
+Package pycompat reproduces Python's random module on a Mersenne Twister of mtrand.
+
+Python's random.Random is MT19937, the same generator with mtrand.MT32,
+and Random in this package generates the same numbers with CPython 3 for the same seed.
+
+	r := pycompat.New(mtrand.NewMT32())
+	r.SeedInt(12345)  // random.seed(12345)
+	x := r.Random()   // random.random()
+	n := r.RandInt(1, 6) // random.randint(1, 6)
+
+Functions that take a sequence in Python take its length here and return indices,
+like math/rand.Perm() and math/rand.Shuffle().
+Like math/rand, invalid arguments cause a panic, where Python raises ValueError or IndexError.
+*/
+package pycompat
+
+import (
+	"crypto/sha512"
+	"math"
+	"math/big"
+	"math/bits"
+	"sort"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/internal/libm"
+)
+
+// Random is a random number generator with the algorithms of Python's random.Random
+type Random struct {
+	mt        *mtrand.MT32
+	gaussNext float64 // saved number of Gauss()
+	hasGauss  bool    // true if gaussNext is valid; gauss_next is not None
+}
+
+// New() creates a Random on mt. The state of mt is used as is until one of seeding functions is called.
+func New(mt *mtrand.MT32) *Random {
+	return &Random{mt: mt}
+}
+
+// MT32() returns the underlying generator
+func (r *Random) MT32() *mtrand.MT32 {
+	return r.mt
+}
+
+//
+// seeding
+//
+
+// SeedBigInt() is random.seed(a) for an int. The sign of a is ignored.
+func (r *Random) SeedBigInt(a *big.Int) {
+	// split the absolute value into 32-bit words, from the least significant one
+	x := new(big.Int).Abs(a)
+	key := make([]uint32, 0, x.BitLen()/32+1)
+	mask := big.NewInt(0xffff_ffff)
+	w := new(big.Int)
+	for x.Sign() > 0 {
+		key = append(key, uint32(w.And(x, mask).Uint64()))
+		x.Rsh(x, 32)
+	}
+	if len(key) == 0 {
+		key = append(key, 0)
+	}
+	r.mt.InitByArray(key)
+	r.hasGauss = false
+}
+
+// SeedInt() is random.seed(a) for an int
+func (r *Random) SeedInt(a int64) {
+	r.SeedBigInt(big.NewInt(a))
+}
+
+// SeedBytes() is random.seed(a) for bytes, with the default version 2
+func (r *Random) SeedBytes(a []byte) {
+	sum := sha512.Sum512(a)
+	b := append(append([]byte(nil), a...), sum[:]...)
+	r.SeedBigInt(new(big.Int).SetBytes(b))
+}
+
+// SeedString() is random.seed(a) for a str, with the default version 2
+func (r *Random) SeedString(a string) {
+	r.SeedBytes([]byte(a)) // UTF-8, same with str.encode()
+}
+
+//
+// integers
+//
+
+// GetRandBits() is random.getrandbits(k)
+func (r *Random) GetRandBits(k int) *big.Int {
+	if k < 0 {
+		panic("pycompat: number of bits must be non-negative")
+	}
+	if k <= 64 {
+		return new(big.Int).SetUint64(r.GetRandBits64(k))
+	}
+	// fill words from the least significant one
+	words := (k-1)/32 + 1
+	b := make([]byte, words*4)
+	for i := 0; i < words; i++ {
+		v := r.mt.GenUint32()
+		if k < 32 {
+			v >>= 32 - k
+		}
+		j := len(b) - 4*i // big endian for big.Int
+		b[j-4], b[j-3], b[j-2], b[j-1] = byte(v>>24), byte(v>>16), byte(v>>8), byte(v)
+		k -= 32
+	}
+	return new(big.Int).SetBytes(b)
+}
+
+// GetRandBits64() is random.getrandbits(k) for k <= 64
+func (r *Random) GetRandBits64(k int) uint64 {
+	switch {
+	case k < 0 || k > 64:
+		panic("pycompat: invalid number of bits")
+	case k == 0:
+		return 0
+	case k <= 32:
+		return uint64(r.mt.GenUint32() >> (32 - k))
+	}
+	lo := uint64(r.mt.GenUint32())
+	hi := uint64(r.mt.GenUint32() >> (64 - k))
+	return hi<<32 | lo
+}
+
+// randBelow() is random._randbelow(n), for n > 0
+func (r *Random) randBelow(n uint64) uint64 {
+	k := bits.Len64(n)
+	v := r.GetRandBits64(k)
+	for v >= n {
+		v = r.GetRandBits64(k)
+	}
+	return v
+}
+
+// RandBelow() is random._randbelow(n); it returns 0 for n == 0
+func (r *Random) RandBelow(n int64) int64 {
+	if n < 0 {
+		panic("pycompat: invalid argument to RandBelow")
+	}
+	if n == 0 {
+		return 0
+	}
+	return int64(r.randBelow(uint64(n)))
+}
+
+// RandRange() is random.randrange(start, stop, step)
+func (r *Random) RandRange(start, stop, step int64) int64 {
+	// the width and the number of values are computed in uint64, as they may not fit in int64
+	var width, ustep uint64
+	switch {
+	case step > 0 && stop > start:
+		width, ustep = uint64(stop-start), uint64(step)
+	case step < 0 && stop < start:
+		width, ustep = uint64(start-stop), uint64(-step)
+	case step == 0:
+		panic("pycompat: zero step for randrange()")
+	default:
+		panic("pycompat: empty range for randrange()")
+	}
+	if ustep == 1 {
+		return start + step*int64(r.randBelow(width))
+	}
+	n := width / ustep // the ceiling of width/ustep; (width + ustep - 1) / ustep of Python
+	if width%ustep != 0 {
+		n++
+	}
+	return start + step*int64(r.randBelow(n))
+}
+
+// RandRangeN() is random.randrange(stop)
+func (r *Random) RandRangeN(stop int64) int64 {
+	if stop <= 0 {
+		panic("pycompat: empty range for randrange()")
+	}
+	return int64(r.randBelow(uint64(stop)))
+}
+
+// RandInt() is random.randint(a, b); a random integer on [a, b]
+func (r *Random) RandInt(a, b int64) int64 {
+	if b < a {
+		panic("pycompat: empty range for randrange()")
+	}
+	width := uint64(b-a) + 1
+	if width == 0 {
+		// the whole range of int64; _randbelow(2**64) takes getrandbits(65),
+		// of which the lower 64 bits are getrandbits(64) and the top bit is the MSB of the next word
+		for {
+			v := r.GetRandBits64(64)
+			if r.mt.GenUint32()>>31 == 0 {
+				return a + int64(v)
+			}
+		}
+	}
+	return a + int64(r.randBelow(width))
+}
+
+//
+// floats
+//
+
+// Random() is random.random(); a float on [0, 1) with 53-bit resolution
+func (r *Random) Random() float64 {
+	return r.mt.GenRes53()
+}
+
+//
+// sequences
+//
+
+// Choice() is random.choice(seq) for a sequence of length n; it returns an index of seq
+func (r *Random) Choice(n int) int {
+	if n <= 0 {
+		panic("pycompat: cannot choose from an empty sequence")
+	}
+	return int(r.randBelow(uint64(n)))
+}
+
+// Shuffle() is random.shuffle(x) for a sequence of length n; swap swaps the elements with indexes i and j
+func (r *Random) Shuffle(n int, swap func(i, j int)) {
+	for i := n - 1; i > 0; i-- {
+		j := int(r.randBelow(uint64(i + 1)))
+		swap(i, j)
+	}
+}
+
+// Sample() is random.sample(population, k) for a population of length n; it returns indices of population
+func (r *Random) Sample(n, k int) []int {
+	if k < 0 || k > n {
+		panic("pycompat: sample larger than population or is negative")
+	}
+	result := make([]int, k)
+	setsize := 21 // size of a small set minus size of an empty list
+	if k > 5 {
+		setsize += int(math.Pow(4, math.Ceil(libm.Log(float64(k*3))/libm.Log(4)))) // table size for big sets
+	}
+	if n <= setsize {
+		// an n-length list is smaller than a k-length set
+		pool := make([]int, n)
+		for i := range pool {
+			pool[i] = i
+		}
+		for i := 0; i < k; i++ {
+			j := int(r.randBelow(uint64(n - i)))
+			result[i] = pool[j]
+			pool[j] = pool[n-i-1] // move non-selected item into vacancy
+		}
+	} else {
+		selected := make(map[int]bool, k)
+		for i := 0; i < k; i++ {
+			j := int(r.randBelow(uint64(n)))
+			for selected[j] {
+				j = int(r.randBelow(uint64(n)))
+			}
+			selected[j] = true
+			result[i] = j
+		}
+	}
+	return result
+}
+
+// Choices() is random.choices(population, k=k) for a population of length n; it returns indices of population
+func (r *Random) Choices(n, k int) []int {
+	result := make([]int, k)
+	fn := float64(n)
+	for i := range result {
+		result[i] = int(math.Floor(r.Random() * fn))
+	}
+	return result
+}
+
+// ChoicesWeighted() is random.choices(population, weights=weights, k=k); it returns indices of population
+func (r *Random) ChoicesWeighted(weights []float64, k int) []int {
+	cum := make([]float64, len(weights))
+	sum := 0.0
+	for i, w := range weights {
+		sum += w
+		cum[i] = sum
+	}
+	return r.ChoicesCumWeighted(cum, k)
+}
+
+// ChoicesCumWeighted() is random.choices(population, cum_weights=cumWeights, k=k); it returns indices of population
+func (r *Random) ChoicesCumWeighted(cumWeights []float64, k int) []int {
+	n := len(cumWeights)
+	if n == 0 {
+		panic("pycompat: cannot choose from an empty population")
+	}
+	total := cumWeights[n-1]
+	if !(total > 0) {
+		panic("pycompat: total of weights must be greater than zero")
+	}
+	if math.IsInf(total, 0) || math.IsNaN(total) {
+		panic("pycompat: total of weights must be finite")
+	}
+	hi := n - 1
+	result := make([]int, k)
+	for i := range result {
+		x := r.Random() * total
+		// bisect.bisect_right(cumWeights, x, 0, hi)
+		result[i] = sort.Search(hi, func(j int) bool { return cumWeights[j] > x })
+	}
+	return result
+}
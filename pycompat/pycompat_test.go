@@ -0,0 +1,152 @@
+package pycompat_test
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/pycompat"
+)
+
+// compare with outputs of CPython 3.11
+func TestRandom(t *testing.T) {
+	r := pycompat.New(mtrand.NewMT32())
+
+	// random.Random(12345).random()
+	r.SeedInt(12345)
+	target1 := []float64{0.41661987254534116, 0.010169169457068361, 0.8252065092537432}
+	for i, v := range target1 {
+		if x := r.Random(); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+
+	// a big negative seed
+	seed, _ := new(big.Int).SetString("-12345678901234567890123", 10)
+	r.SeedBigInt(seed)
+	target2 := []uint64{678764485, 4198308164, 4236283067}
+	for i, v := range target2 {
+		if x := r.GetRandBits64(32); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+
+	// str and bytes seeds
+	target3 := []int64{362, 862, 679, 776, 560}
+	for _, seed := range []func(){func() { r.SeedString("hello") }, func() { r.SeedBytes([]byte("hello")) }} {
+		seed()
+		for i, v := range target3 {
+			if x := r.RandRangeN(1000); x != v {
+				t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+			}
+		}
+	}
+
+	// getrandbits
+	r.SeedInt(0)
+	target4 := []string{"1", "827307999", "7550356652", "1208935935994293442776782246997", "8963783824838420066"}
+	for i, k := range []int{1, 31, 33, 100, 64} {
+		if x := r.GetRandBits(k).String(); x != target4[i] {
+			t.Errorf("invalid value for getrandbits(%d): expected %v, actual %v", k, target4[i], x)
+		}
+	}
+
+	// randrange and randint
+	r.SeedInt(1)
+	target5 := []int64{7, -2, -8, -8, -8, 10}
+	for i, v := range target5 {
+		if x := r.RandRange(10, -10, -3); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+	target6 := []int64{-1, -4, 2, 2, 2, 5}
+	for i, v := range target6 {
+		if x := r.RandInt(-5, 5); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+	if x := r.RandRangeN(1 << 40); x != 207060179245 {
+		t.Errorf("invalid value for randrange(2**40): %v", x)
+	}
+}
+
+// compare sequence functions with outputs of CPython 3.11
+func TestRandomSequence(t *testing.T) {
+	r := pycompat.New(mtrand.NewMT32())
+
+	r.SeedInt(2)
+	choice := make([]int, 8)
+	for i := range choice {
+		choice[i] = r.Choice(7)
+	}
+	if v := []int{6, 6, 0, 0, 0, 2, 6, 1}; !reflect.DeepEqual(choice, v) {
+		t.Errorf("invalid choice: expected %v, actual %v", v, choice)
+	}
+
+	r.SeedInt(3)
+	x := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	r.Shuffle(len(x), func(i, j int) { x[i], x[j] = x[j], x[i] })
+	if v := []int{1, 5, 6, 0, 9, 4, 7, 2, 8, 3}; !reflect.DeepEqual(x, v) {
+		t.Errorf("invalid shuffle: expected %v, actual %v", v, x)
+	}
+
+	r.SeedInt(4)
+	if s, v := r.Sample(10, 4), []int{3, 4, 1, 5}; !reflect.DeepEqual(s, v) {
+		t.Errorf("invalid sample: expected %v, actual %v", v, s)
+	}
+	if s, v := r.Sample(1000, 10), []int{405, 490, 158, 92, 68, 20, 411, 562, 939, 296}; !reflect.DeepEqual(s, v) {
+		t.Errorf("invalid sample: expected %v, actual %v", v, s)
+	}
+
+	// ranges up to the limits of int64, compared with CPython 3.8 and 3.13
+	r.SeedInt(12345)
+	for i, v := range []int64{4985137385606203988, 8046993897209325893, 6881813287187799947} {
+		if n := r.RandInt(0, math.MaxInt64); n != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d", i, v, n)
+		}
+	}
+	for i, v := range []int64{6902298253804434516, 4295687359971965903, -6490557579181914754, -7787440037216503027} {
+		if n := r.RandInt(math.MinInt64, math.MaxInt64); n != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d", i, v, n)
+		}
+	}
+	for i, v := range []int64{9223372036854775806, 9223372036854775806, 9223372036854775805} {
+		if n := r.RandInt(math.MaxInt64-2, math.MaxInt64); n != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d", i, v, n)
+		}
+	}
+	r.SeedInt(12345)
+	for i, v := range []int64{746902734357632168, 6870615757563875978, 4540254537520824086} {
+		if n := r.RandRange(math.MinInt64, math.MaxInt64, 2); n != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d", i, v, n)
+		}
+	}
+	for i, v := range []int64{1998981360001303582, -8131732460014477421, -7704356120707815068} {
+		if n := r.RandRange(math.MaxInt64, math.MinInt64, -3); n != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d", i, v, n)
+		}
+	}
+	for i, v := range []int64{9223372036854775806, -9223372036854775808, -1} {
+		if n := r.RandRange(math.MinInt64, math.MaxInt64, math.MaxInt64); n != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d", i, v, n)
+		}
+	}
+	for i, v := range []int64{9223372036854775807, -1, 9223372036854775807} {
+		if n := r.RandRange(math.MaxInt64, math.MinInt64, math.MinInt64); n != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d", i, v, n)
+		}
+	}
+
+	r.SeedInt(5)
+	if s, v := r.Choices(5, 6), []int{3, 3, 3, 4, 3, 4}; !reflect.DeepEqual(s, v) {
+		t.Errorf("invalid choices: expected %v, actual %v", v, s)
+	}
+	if s, v := r.ChoicesWeighted([]float64{1, 2, 3, 4}, 6), []int{0, 2, 3, 3, 3, 1}; !reflect.DeepEqual(s, v) {
+		t.Errorf("invalid choices: expected %v, actual %v", v, s)
+	}
+	if s, v := r.ChoicesCumWeighted([]float64{1, 3, 6, 10}, 4), []int{2, 1, 2, 2}; !reflect.DeepEqual(s, v) {
+		t.Errorf("invalid choices: expected %v, actual %v", v, s)
+	}
+}
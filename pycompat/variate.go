@@ -0,0 +1,201 @@
+/*
+	variate.go
+	continuous distributions of Python's random module
+
+	The results depend on the C math library that CPython uses.
+	Functions of internal/libm are used to get the same results with glibc.
+*/
+
+package pycompat
+
+import (
+	"math"
+
+	"github.com/mixcode/golib-mtrand/internal/libm"
+)
+
+var (
+	nvMagicConst = 4 * libm.Exp(-0.5) / math.Sqrt(2.0)
+	twoPi        = 2.0 * math.Pi
+	log4         = libm.Log(4.0)
+	sgMagicConst = 1.0 + libm.Log(4.5)
+)
+
+// Python's % operator for floats
+func floatMod(x, y float64) float64 {
+	m := math.Mod(x, y)
+	if m != 0 {
+		if (y < 0) != (m < 0) {
+			m += y
+		}
+	} else {
+		m = math.Copysign(0, y)
+	}
+	return m
+}
+
+// Triangular() is random.triangular(low, high, mode)
+func (r *Random) Triangular(low, high, mode float64) float64 {
+	return r.triangular(low, high, (mode-low)/(high-low))
+}
+
+// TriangularDefault() is random.triangular(low, high), with the default mode at the midpoint
+func (r *Random) TriangularDefault(low, high float64) float64 {
+	return r.triangular(low, high, 0.5)
+}
+
+func (r *Random) triangular(low, high, c float64) float64 {
+	u := r.Random()
+	if high == low { // ZeroDivisionError in Python
+		return low
+	}
+	if u > c {
+		u = 1.0 - u
+		c = 1.0 - c
+		low, high = high, low
+	}
+	return low + float64((high-low)*math.Sqrt(u*c))
+}
+
+// NormalVariate() is random.normalvariate(mu, sigma), by Kinderman and Monahan method
+func (r *Random) NormalVariate(mu, sigma float64) float64 {
+	var z float64
+	for {
+		u1 := r.Random()
+		u2 := 1.0 - r.Random()
+		z = nvMagicConst * (u1 - 0.5) / u2
+		zz := z * z / 4.0
+		if zz <= -libm.Log(u2) {
+			break
+		}
+	}
+	return mu + float64(z*sigma)
+}
+
+// Gauss() is random.gauss(mu, sigma), by Box-Muller method.
+// The second number of a pair is kept for the next call, as gauss_next of Python.
+func (r *Random) Gauss(mu, sigma float64) float64 {
+	z, ok := r.gaussNext, r.hasGauss
+	r.hasGauss = false
+	if !ok {
+		x2pi := r.Random() * twoPi
+		g2rad := math.Sqrt(-2.0 * libm.Log(1.0-r.Random()))
+		z = libm.Cos(x2pi) * g2rad
+		r.gaussNext, r.hasGauss = libm.Sin(x2pi)*g2rad, true
+	}
+	return mu + float64(z*sigma)
+}
+
+// LogNormVariate() is random.lognormvariate(mu, sigma)
+func (r *Random) LogNormVariate(mu, sigma float64) float64 {
+	return libm.Exp(r.NormalVariate(mu, sigma))
+}
+
+// ExpoVariate() is random.expovariate(lambd)
+func (r *Random) ExpoVariate(lambd float64) float64 {
+	return -libm.Log(1.0-r.Random()) / lambd
+}
+
+// VonMisesVariate() is random.vonmisesvariate(mu, kappa)
+func (r *Random) VonMisesVariate(mu, kappa float64) float64 {
+	if kappa <= 1e-6 {
+		return twoPi * r.Random()
+	}
+	s := 0.5 / kappa
+	rr := s + math.Sqrt(1.0+float64(s*s))
+
+	var z float64
+	for {
+		u1 := r.Random()
+		z = libm.Cos(math.Pi * u1)
+		d := z / (rr + z)
+		u2 := r.Random()
+		if u2 < 1.0-float64(d*d) || u2 <= (1.0-d)*libm.Exp(d) {
+			break
+		}
+	}
+
+	q := 1.0 / rr
+	f := (q + z) / (1.0 + float64(q*z))
+	u3 := r.Random()
+	if u3 > 0.5 {
+		return floatMod(mu+libm.Acos(f), twoPi)
+	}
+	return floatMod(mu-libm.Acos(f), twoPi)
+}
+
+// GammaVariate() is random.gammavariate(alpha, beta)
+func (r *Random) GammaVariate(alpha, beta float64) float64 {
+	if alpha <= 0.0 || beta <= 0.0 {
+		panic("pycompat: gammavariate: alpha and beta must be > 0.0")
+	}
+
+	if alpha > 1.0 {
+		// R.C.H. Cheng, "The generation of Gamma variables with non-integral shape parameters"
+		ainv := math.Sqrt(2.0*alpha - 1.0)
+		bbb := alpha - log4
+		ccc := alpha + ainv
+		for {
+			u1 := r.Random()
+			if !(1e-7 < u1 && u1 < 0.9999999) {
+				continue
+			}
+			u2 := 1.0 - r.Random()
+			v := libm.Log(u1/(1.0-u1)) / ainv
+			x := float64(alpha * libm.Exp(v))
+			z := u1 * u1 * u2
+			rr := bbb + float64(ccc*v) - x
+			if rr+sgMagicConst-float64(4.5*z) >= 0.0 || rr >= libm.Log(z) {
+				return x * beta
+			}
+		}
+	}
+
+	if alpha == 1.0 {
+		// expovariate(1/beta)
+		return -libm.Log(1.0-r.Random()) * beta
+	}
+
+	// alpha is between 0 and 1; ALGORITHM GS of Statistical Computing - Kennedy & Gentle
+	var x float64
+	for {
+		u := r.Random()
+		b := (math.E + alpha) / math.E
+		p := float64(b * u)
+		if p <= 1.0 {
+			x = libm.Pow(p, 1.0/alpha)
+		} else {
+			x = -libm.Log((b - p) / alpha)
+		}
+		u1 := r.Random()
+		if p > 1.0 {
+			if u1 <= libm.Pow(x, alpha-1.0) {
+				break
+			}
+		} else if u1 <= libm.Exp(-x) {
+			break
+		}
+	}
+	return x * beta
+}
+
+// BetaVariate() is random.betavariate(alpha, beta)
+func (r *Random) BetaVariate(alpha, beta float64) float64 {
+	y := r.GammaVariate(alpha, 1.0)
+	if y != 0 {
+		return y / (y + r.GammaVariate(beta, 1.0))
+	}
+	return 0.0
+}
+
+// ParetoVariate() is random.paretovariate(alpha)
+func (r *Random) ParetoVariate(alpha float64) float64 {
+	u := 1.0 - r.Random()
+	return libm.Pow(u, -1.0/alpha)
+}
+
+// WeibullVariate() is random.weibullvariate(alpha, beta)
+func (r *Random) WeibullVariate(alpha, beta float64) float64 {
+	u := 1.0 - r.Random()
+	return alpha * libm.Pow(-libm.Log(u), 1.0/beta)
+}
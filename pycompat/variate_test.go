@@ -0,0 +1,51 @@
+package pycompat_test
+
+import (
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/pycompat"
+)
+
+// compare with outputs of CPython 3.11 on glibc, from a single random.Random(1)
+func TestVariate(t *testing.T) {
+	r := pycompat.New(mtrand.NewMT32())
+	r.SeedInt(1)
+
+	target := []struct {
+		name string
+		f    func() float64
+		v    []float64
+	}{
+		{"gauss", func() float64 { return r.Gauss(1.0, 2.0) }, []float64{3.5763695063109258, 3.898891217399542, 1.132671617876524, -0.5290873019432636, -1.184346430208283}},
+		{"normalvariate", func() float64 { return r.NormalVariate(0.5, 3.0) }, []float64{4.192721687349982, 3.5464435015314093, -0.5093707829519258, 4.152445425949823}},
+		{"lognormvariate", func() float64 { return r.LogNormVariate(0.1, 0.5) }, []float64{0.7256719952350572, 0.9943455342632961, 0.8206378859508277, 0.707247262910439}},
+		{"expovariate", func() float64 { return r.ExpoVariate(1.5) }, []float64{0.22812184743836458, 0.01448264751144991, 1.2117048284056848, 0.5419696597626577}},
+		{"gammavariate", func() float64 { return r.GammaVariate(2.5, 1.5) }, []float64{5.024992649877722, 1.390608130544347, 6.035593549713418, 8.287065821720995}},
+		{"gammavariate", func() float64 { return r.GammaVariate(1.0, 2.0) }, []float64{0.7229974362566918, 1.7714290022701327, 4.282276490082336, 3.744170873599843}},
+		{"gammavariate", func() float64 { return r.GammaVariate(0.3, 1.0) }, []float64{0.14565844335753622, 1.899757303850277e-05, 0.6665194688050816, 0.004090408058971861}},
+		{"betavariate", func() float64 { return r.BetaVariate(2.0, 3.0) }, []float64{0.5795976235965852, 0.3956841206269447, 0.7217158616443867, 0.3409634226664859}},
+		{"vonmisesvariate", func() float64 { return r.VonMisesVariate(1.0, 4.0) }, []float64{1.5097677021466405, 1.4319043476355557, 3.613462287982741, 0.4826496160247832}},
+		{"vonmisesvariate", func() float64 { return r.VonMisesVariate(1.0, 0.0) }, []float64{0.3526330783727847, 5.466435024102895}},
+		{"paretovariate", func() float64 { return r.ParetoVariate(2.5) }, []float64{1.4015621114355394, 1.0932743003317915, 1.3245240474236228, 1.3039227100267168}},
+		{"weibullvariate", func() float64 { return r.WeibullVariate(1.5, 2.0) }, []float64{0.9964381101795413, 0.977612358206563, 1.3190001437908156, 1.4825048874159479}},
+		{"triangular", func() float64 { return r.Triangular(1.0, 5.0, 2.0) }, []float64{2.8434818751962014, 2.450051294868207, 1.3345144785138148, 1.95834238407163}},
+		{"triangular", func() float64 { return r.TriangularDefault(0.0, 1.0) }, []float64{0.2976669774585205, 0.5441825314769306}},
+	}
+	for _, c := range target {
+		for i, v := range c.v {
+			if x := c.f(); x != v {
+				t.Errorf("%s: invalid value for iteration %d: expected %v, actual %v", c.name, i, v, x)
+			}
+		}
+	}
+
+	// seeding discards the saved number of gauss()
+	r.Gauss(0, 1)
+	r.SeedInt(7)
+	for i, v := range []float64{-0.2558802884476004, 0.511431512516514, -0.2260961647831047} {
+		if x := r.Gauss(0, 1); x != v {
+			t.Errorf("gauss: invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+}
@@ -0,0 +1,344 @@
+/*
+Package rcompat reproduces the default random number generator of R on a Mersenne Twister of mtrand.
+
+R's default RNG kind is "Mersenne-Twister", the same generator with mtrand.MT32,
+with normal.kind "Inversion" and sample.kind "Rejection" of R 3.6.0 and later.
+RNG in this package generates the same numbers with R for the same seed.
+
+	r := rcompat.New(mtrand.NewMT32())
+	r.SetSeed(42)             // set.seed(42)
+	x := r.Runif(0, 1)        // runif(1)
+	z := r.Rnorm(0, 1)        // rnorm(1)
+	p := r.Sample(10, 10, false) // sample(10) - 1
+
+R was not available to capture values in full precision, so runif() and rnorm() are tested against
+the numbers R prints, in 7 significant digits, and unif_rand() against a C transcription of MT_genrand() of R.
+
+Sample() returns 0-based indices, where R returns 1-based ones.
+Like R, the distribution functions return NaN for invalid parameters.
+*/
+package rcompat
+
+import (
+	"math"
+	"math/bits"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/internal/libm"
+)
+
+// SampleKind is the algorithm of sample(), RNGkind(sample.kind=)
+type SampleKind int
+
+const (
+	SampleRounding  SampleKind = 0 // "Rounding", the default before R 3.6.0
+	SampleRejection SampleKind = 1 // "Rejection", the default of R 3.6.0 and later
+)
+
+const (
+	kindMersenneTwister = 3 // RNGkind "Mersenne-Twister"
+	kindInversion       = 4 // normal.kind "Inversion"
+
+	mtN = 624
+)
+
+// RNG is a random number generator with the algorithms of R
+type RNG struct {
+	mt     *mtrand.MT32
+	sample SampleKind
+	fresh  bool // the index of .Random.seed is 625; MT_sgenrand(4357) is done at the next draw
+}
+
+// New() creates an RNG on mt with SampleRejection. The state of mt is used as is until SetSeed() or SetRandomSeed() is called.
+func New(mt *mtrand.MT32) *RNG {
+	return &RNG{mt: mt, sample: SampleRejection}
+}
+
+// MT32() returns the underlying generator
+func (r *RNG) MT32() *mtrand.MT32 {
+	return r.mt
+}
+
+// SampleKind() returns the algorithm of Sample()
+func (r *RNG) SampleKind() SampleKind {
+	return r.sample
+}
+
+// SetSampleKind() is RNGkind(sample.kind=k)
+func (r *RNG) SetSampleKind(k SampleKind) {
+	if k != SampleRounding && k != SampleRejection {
+		panic("rcompat: invalid sample kind")
+	}
+	r.sample = k
+}
+
+//
+// seeding
+//
+
+// SetSeed() is set.seed(seed). The seed is scrambled by an LCG, and the state is filled by the LCG.
+func (r *RNG) SetSeed(seed int32) {
+	s := uint32(seed)
+	for j := 0; j < 50; j++ {
+		s = 69069*s + 1
+	}
+	s = 69069*s + 1 // the first word is the index, which is reset below
+	state := make([]uint32, mtN)
+	for j := range state {
+		s = 69069*s + 1
+		state[j] = s
+	}
+	r.mt.InitByState(state, mtN)
+	r.fresh = false
+}
+
+// MT_sgenrand() of R; the initialization of the 1998 version of MT19937
+func sgenrand(seed uint32) []uint32 {
+	state := make([]uint32, mtN)
+	for i := range state {
+		state[i] = seed & 0xffff_0000
+		seed = 69069*seed + 1
+		state[i] |= (seed & 0xffff_0000) >> 16
+		seed = 69069*seed + 1
+	}
+	return state
+}
+
+// RandomSeed() returns the value of .Random.seed; the RNG kinds, the index and the state words
+func (r *RNG) RandomSeed() []int32 {
+	state, i := r.mt.State()
+	seed := make([]int32, 2+mtN)
+	seed[0] = kindMersenneTwister + 100*kindInversion + 10000*int32(r.sample)
+	seed[1] = int32(i)
+	if r.fresh {
+		seed[1] = mtN + 1
+	}
+	for j, v := range state {
+		seed[2+j] = int32(v)
+	}
+	return seed
+}
+
+// SetRandomSeed() restores a value of .Random.seed.
+// The index 625 means that the state is not initialized, and the state is replaced by MT_sgenrand(4357) at the next draw, as R does.
+// It panics if the value is not of "Mersenne-Twister" and "Inversion", or the state is all zero.
+func (r *RNG) SetRandomSeed(seed []int32) {
+	if len(seed) != 2+mtN {
+		panic("rcompat: invalid length of .Random.seed")
+	}
+	kind := seed[0]
+	if kind%100 != kindMersenneTwister || kind/100%100 != kindInversion {
+		panic("rcompat: unsupported RNG kind")
+	}
+	sample := SampleKind(kind / 10000)
+	if sample != SampleRounding && sample != SampleRejection {
+		panic("rcompat: invalid sample kind")
+	}
+	i := int(seed[1])
+	if i <= 0 { // FixupSeeds()
+		i = mtN
+	}
+	if i > mtN+1 {
+		panic("rcompat: invalid .Random.seed")
+	}
+	state := make([]uint32, mtN)
+	zero := true
+	for j := range state {
+		state[j] = uint32(seed[2+j])
+		if state[j] != 0 {
+			zero = false
+		}
+	}
+	if zero {
+		panic("rcompat: .Random.seed is all zero")
+	}
+	r.fresh = i == mtN+1
+	if r.fresh {
+		i = mtN
+	}
+	r.mt.InitByState(state, i)
+	r.sample = sample
+}
+
+//
+// uniform
+//
+
+// 1/(2^32-1)
+const i2_32m1 = 2.328306437080797e-10
+
+// UnifRand() is unif_rand(); a number on (0, 1)
+func (r *RNG) UnifRand() float64 {
+	if r.fresh {
+		r.mt.InitByState(sgenrand(4357), mtN)
+		r.fresh = false
+	}
+	x := float64(float64(r.mt.GenUint32()) * 2.3283064365386963e-10) // [0, 1)
+	// fixup(); ensure 0 and 1 are never returned
+	if x <= 0.0 {
+		return 0.5 * i2_32m1
+	}
+	if 1.0-x <= 0.0 {
+		return 1.0 - 0.5*i2_32m1
+	}
+	return x
+}
+
+// Runif() is runif(1, a, b)
+func (r *RNG) Runif(a, b float64) float64 {
+	if math.IsInf(a, 0) || math.IsNaN(a) || math.IsInf(b, 0) || math.IsNaN(b) || b < a {
+		return math.NaN()
+	}
+	if a == b {
+		return a
+	}
+	u := r.UnifRand()
+	return a + float64((b-a)*u)
+}
+
+//
+// normal
+//
+
+// NormRand() is norm_rand() of "Inversion"
+func (r *RNG) NormRand() float64 {
+	const big = 134217728 // 2^27
+	// unif_rand() alone is not of high enough precision
+	u := r.UnifRand()
+	u = float64(int32(big*u)) + r.UnifRand()
+	return qnorm(u / big)
+}
+
+// Rnorm() is rnorm(1, mu, sigma)
+func (r *RNG) Rnorm(mu, sigma float64) float64 {
+	if math.IsNaN(mu) || math.IsInf(sigma, 0) || math.IsNaN(sigma) || sigma < 0 {
+		return math.NaN()
+	}
+	if sigma == 0 || math.IsInf(mu, 0) {
+		return mu
+	}
+	return mu + float64(sigma*r.NormRand())
+}
+
+// horner() evaluates c[0]*r^n + c[1]*r^(n-1) + ... + c[n], rounding each product as C does without FMA
+func horner(r float64, c ...float64) float64 {
+	v := c[0]
+	for _, k := range c[1:] {
+		v = float64(v*r) + k
+	}
+	return v
+}
+
+// qnorm(p, 0, 1) for 0 < p < 1, by Wichura's AS241 as in R
+func qnorm(p float64) float64 {
+	q := p - 0.5
+	if math.Abs(q) <= 0.425 { // 0.075 <= p <= 0.925
+		r := 0.180625 - float64(q*q)
+		return q * horner(r,
+			2509.0809287301226727, 33430.575583588128105, 67265.770927008700853, 45921.953931549871457,
+			13731.693765509461125, 1971.5909503065514427, 133.14166789178437745, 3.387132872796366608) /
+			horner(r,
+				5226.495278852545925, 28729.085735721942674, 39307.89580009271061, 21213.794301586595867,
+				5394.1960214247511077, 687.1870074920579083, 42.313330701600911252, 1.0)
+	}
+
+	// closer than 0.075 from {0,1} boundary
+	r := p
+	if q > 0 {
+		r = 1 - p
+	}
+	r = math.Sqrt(-libm.Log(r))
+	var val float64
+	if r <= 5.0 { // min(p,1-p) >= exp(-25)
+		r += -1.6
+		val = horner(r,
+			7.7454501427834140764e-4, 0.0227238449892691845833, 0.24178072517745061177, 1.27045825245236838258,
+			3.64784832476320460504, 5.7694972214606914055, 4.6303378461565452959, 1.42343711074968357734) /
+			horner(r,
+				1.05075007164441684324e-9, 5.475938084995344946e-4, 0.0151986665636164571966, 0.14810397642748007459,
+				0.68976733498510000455, 1.6763848301838038494, 2.05319162663775882187, 1.0)
+	} else { // very close to 0 or 1
+		r += -5.0
+		val = horner(r,
+			2.01033439929228813265e-7, 2.71155556874348757815e-5, 0.0012426609473880784386, 0.026532189526576123093,
+			0.29656057182850489123, 1.7848265399172913358, 5.4637849111641143699, 6.6579046435011037772) /
+			horner(r,
+				2.04426310338993978564e-15, 1.4215117583164458887e-7, 1.8463183175100546818e-5, 7.868691311456132591e-4,
+				0.0148753612908506148525, 0.13692988092273580531, 0.59983220655588793769, 1.0)
+	}
+	if q < 0.0 {
+		val = -val
+	}
+	return val
+}
+
+//
+// sample
+//
+
+// rbits(); a number of the given bits, from 16-bit chunks of unif_rand()
+func (r *RNG) rbits(nbits int) uint64 {
+	var v uint64
+	for n := 0; n <= nbits; n += 16 {
+		v1 := uint64(math.Floor(r.UnifRand() * 65536))
+		v = 65536*v + v1
+	}
+	return v & (1<<nbits - 1)
+}
+
+// R_unif_index(); a number on [0, n)
+func (r *RNG) unifIndex(n int) int {
+	if r.sample == SampleRounding {
+		return int(math.Floor(float64(n) * r.UnifRand()))
+	}
+	if n <= 0 {
+		return 0
+	}
+	// rejection sampling from integers below the next power of two
+	nbits := bits.Len64(uint64(n - 1)) // ceil(log2(n))
+	for {
+		if v := r.rbits(nbits); v < uint64(n) {
+			return int(v)
+		}
+	}
+}
+
+// Sample() is sample.int(n, size, replace) without prob; it returns 0-based indices
+func (r *RNG) Sample(n, size int, replace bool) []int {
+	if n < 0 || size < 0 {
+		panic("rcompat: invalid argument")
+	}
+	if !replace && size > n {
+		panic("rcompat: cannot take a sample larger than the population when 'replace = FALSE'")
+	}
+	y := make([]int, size)
+	switch {
+	case replace || size < 2:
+		for i := range y {
+			y[i] = r.unifIndex(n)
+		}
+	case n > 1e7 && size <= n/2:
+		// sample2(); rejection of duplicates by a hash
+		seen := make(map[int]bool, size)
+		for i := 0; i < size; {
+			v := r.unifIndex(n)
+			if !seen[v] {
+				seen[v] = true
+				y[i] = v
+				i++
+			}
+		}
+	default:
+		x := make([]int, n)
+		for i := range x {
+			x[i] = i
+		}
+		for i := range y {
+			j := r.unifIndex(n)
+			y[i] = x[j]
+			n--
+			x[j] = x[n]
+		}
+	}
+	return y
+}
@@ -0,0 +1,140 @@
+package rcompat_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/rcompat"
+)
+
+// compare with outputs of R, printed in 7 significant digits
+func TestRNG(t *testing.T) {
+	r := rcompat.New(mtrand.NewMT32())
+	near := func(x, v float64) bool { return math.Abs(x-v) <= 5e-7*math.Max(1, math.Abs(v)) }
+
+	// set.seed(42); runif(3)
+	r.SetSeed(42)
+	target1 := []float64{0.9148060, 0.9370754, 0.2861395}
+	for i, v := range target1 {
+		if x := r.Runif(0, 1); !near(x, v) {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+
+	// set.seed(123); rnorm(5)
+	r.SetSeed(123)
+	target2 := []float64{-0.56047565, -0.23017749, 1.55870831, 0.07050839, 0.12928774}
+	for i, v := range target2 {
+		if x := r.Rnorm(0, 1); !near(x, v) {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+
+	// set.seed(1); rnorm(5, 10, 2)
+	r.SetSeed(1)
+	target3 := []float64{8.747092, 10.367287, 8.328743, 13.190562, 10.659016}
+	for i, v := range target3 {
+		if x := r.Rnorm(10, 2); !near(x, v) {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+
+	// sample(10) with sample.kind "Rejection"
+	for seed, v := range map[int32][]int{
+		1:   {9, 4, 7, 1, 2, 5, 3, 10, 6, 8},
+		42:  {1, 5, 10, 8, 2, 4, 6, 9, 7, 3},
+		123: {3, 10, 2, 8, 6, 9, 1, 7, 5, 4},
+	} {
+		r.SetSeed(seed)
+		x := r.Sample(10, 10, false)
+		for i := range x {
+			x[i]++
+		}
+		if !reflect.DeepEqual(x, v) {
+			t.Errorf("invalid sample for seed %d: expected %v, actual %v", seed, v, x)
+		}
+	}
+
+	// invalid parameters
+	if x := r.Runif(1, 0); !math.IsNaN(x) {
+		t.Errorf("invalid runif(1, 0): %v", x)
+	}
+	if x := r.Rnorm(0, -1); !math.IsNaN(x) {
+		t.Errorf("invalid rnorm(0, -1): %v", x)
+	}
+}
+
+func TestRNGSample(t *testing.T) {
+	r := rcompat.New(mtrand.NewMT32())
+
+	// "Rounding" is floor(n * unif_rand())
+	r.SetSampleKind(rcompat.SampleRounding)
+	r.SetSeed(7)
+	x := r.Sample(100, 5, true)
+	r.SetSeed(7)
+	for i, v := range x {
+		if u := int(math.Floor(100 * r.UnifRand())); u != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, u, v)
+		}
+	}
+
+	// sampling without replacement gives distinct values, also by the hash for a big population
+	r.SetSampleKind(rcompat.SampleRejection)
+	for _, n := range []int{5, 20000000} {
+		seen := make(map[int]bool)
+		for _, v := range r.Sample(n, 5, false) {
+			if v < 0 || v >= n || seen[v] {
+				t.Errorf("invalid sample of %d: %v", n, v)
+			}
+			seen[v] = true
+		}
+	}
+}
+
+func TestRNGRandomSeed(t *testing.T) {
+	r := rcompat.New(mtrand.NewMT32())
+	r.SetSeed(42)
+	seed := r.RandomSeed()
+	if len(seed) != 626 || seed[0] != 10403 || seed[1] != 624 {
+		t.Errorf("invalid .Random.seed: %v", seed[:2])
+	}
+
+	// a saved .Random.seed continues the sequence
+	for i := 0; i < 1000; i++ {
+		r.UnifRand()
+	}
+	seed = r.RandomSeed()
+	r2 := rcompat.New(mtrand.NewMT32())
+	r2.SetRandomSeed(seed)
+	for i := 0; i < 1000; i++ {
+		if x, v := r2.UnifRand(), r.UnifRand(); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+			break
+		}
+	}
+
+	// the sample kind is restored too
+	seed[0] = 403
+	r2.SetRandomSeed(seed)
+	if r2.SampleKind() != rcompat.SampleRounding {
+		t.Errorf("invalid sample kind: %v", r2.SampleKind())
+	}
+
+	// the index 625 is kept until a draw, which initializes the state by MT_sgenrand(4357);
+	// values from R's MT_genrand() compiled with gcc
+	seed[1] = 625
+	r2.SetRandomSeed(seed)
+	if s := r2.RandomSeed(); s[1] != 625 || s[2] != seed[2] {
+		t.Errorf("invalid .Random.seed: %v", s[:3])
+	}
+	for i, v := range []float64{0.66757647763006389, 0.36908387253060937, 0.72483069472946227} {
+		if x := r2.UnifRand(); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+	if s := r2.RandomSeed(); s[1] != 3 {
+		t.Errorf("invalid index of .Random.seed: %d", s[1])
+	}
+}
@@ -0,0 +1,254 @@
+/*
+Package rubycompat reproduces Ruby's Random class of MRI on a Mersenne Twister of mtrand.
+
+Ruby's Random is MT19937, the same generator with mtrand.MT32,
+and Random in this package generates the same numbers with MRI for the same seed.
+
+	r := rubycompat.New(mtrand.NewMT32())
+	r.Seed(1234)               // r = Random.new(1234)
+	x := r.Rand()              // r.rand
+	n := r.RandInt(6)          // r.rand(6)
+	r.Shuffle(len(a), swap)    // a.shuffle!(random: r)
+
+Ruby was not available to capture values, so the floats of small seeds are tested against the values of NumPy,
+which seeds and generates them by the same algorithms, and the other functions by their algorithms in the MRI sources.
+
+Functions that take an Array in Ruby take its length here and return indices,
+like math/rand.Perm() and math/rand.Shuffle().
+Like math/rand, invalid arguments cause a panic, where Ruby raises ArgumentError.
+*/
+package rubycompat
+
+import (
+	"math/big"
+	"math/bits"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+)
+
+// Random is a random number generator with the algorithms of Ruby's Random
+type Random struct {
+	mt *mtrand.MT32
+}
+
+// New() creates a Random on mt. The state of mt is used as is until Seed() or SeedBigInt() is called.
+func New(mt *mtrand.MT32) *Random {
+	return &Random{mt: mt}
+}
+
+// MT32() returns the underlying generator
+func (r *Random) MT32() *mtrand.MT32 {
+	return r.mt
+}
+
+//
+// seeding
+//
+
+// SeedBigInt() is Random.new(seed) or Random.srand(seed) for an Integer. The sign of seed is ignored.
+func (r *Random) SeedBigInt(seed *big.Int) {
+	// split the absolute value into 32-bit words, from the least significant one
+	x := new(big.Int).Abs(seed)
+	key := make([]uint32, 0, x.BitLen()/32+1)
+	mask := big.NewInt(0xffff_ffff)
+	w := new(big.Int)
+	for x.Sign() > 0 {
+		key = append(key, uint32(w.And(x, mask).Uint64()))
+		x.Rsh(x, 32)
+	}
+	if len(key) <= 1 {
+		if len(key) == 0 {
+			key = append(key, 0)
+		}
+		r.mt.Init(key[0])
+		return
+	}
+	if key[len(key)-1] == 1 { // remove leading-zero-guard
+		key = key[:len(key)-1]
+	}
+	r.mt.InitByArray(key)
+}
+
+// Seed() is Random.new(seed) for an Integer
+func (r *Random) Seed(seed int64) {
+	r.SeedBigInt(big.NewInt(seed))
+}
+
+//
+// integers
+//
+
+// limited_rand(); a number on [0, limit].
+// 32-bit words are taken from the most significant one, and the number is retried as soon as it exceeds limit.
+func (r *Random) limited(limit uint64) uint64 {
+	if limit == 0 {
+		return 0
+	}
+	mask := ^uint64(0) >> bits.LeadingZeros64(limit)
+retry:
+	var v uint64
+	for i := 1; i >= 0; i-- {
+		if (mask>>(i*32))&0xffff_ffff != 0 {
+			v |= uint64(r.mt.GenUint32()) << (i * 32)
+			v &= mask
+			if limit < v {
+				goto retry
+			}
+		}
+	}
+	return v
+}
+
+// RandInt() is rand(max) for an Integer max; a number on [0, max)
+func (r *Random) RandInt(max int64) int64 {
+	if max <= 0 {
+		panic("rubycompat: invalid argument")
+	}
+	return int64(r.limited(uint64(max) - 1))
+}
+
+// RandRange() is rand(beg..end); a number on [beg, end]. For rand(beg...end), use end-1.
+func (r *Random) RandRange(beg, end int64) int64 {
+	if end < beg {
+		panic("rubycompat: invalid argument")
+	}
+	return int64(uint64(beg) + r.limited(uint64(end)-uint64(beg)))
+}
+
+//
+// floats
+//
+
+// Rand() is rand(); a float on [0, 1) with 53-bit resolution
+func (r *Random) Rand() float64 {
+	return r.mt.GenRes53()
+}
+
+// RandFloat() is rand(max) for a Float max; a float on [0, max). rand(0.0) is the same with rand().
+func (r *Random) RandFloat(max float64) float64 {
+	if !(max >= 0) {
+		panic("rubycompat: invalid argument")
+	}
+	v := r.mt.GenRes53()
+	if max > 0 {
+		v *= max
+	}
+	return v
+}
+
+//
+// bytes
+//
+
+// Bytes() is bytes(n); little endian 32-bit numbers, and the last one is truncated
+func (r *Random) Bytes(n int) []byte {
+	b := make([]byte, n)
+	for i := 0; i < n; i += 4 {
+		v := r.mt.GenUint32()
+		for j := i; j < i+4 && j < n; j++ {
+			b[j] = byte(v)
+			v >>= 8
+		}
+	}
+	return b
+}
+
+//
+// sequences
+//
+
+// Shuffle() is Array#shuffle!(random: r) for an Array of length n; swap swaps the elements with indexes i and j
+func (r *Random) Shuffle(n int, swap func(i, j int)) {
+	for i := n; i > 0; {
+		j := int(r.limited(uint64(i - 1)))
+		i--
+		swap(i, j)
+	}
+}
+
+// Sample() is Array#sample(random: r) for an Array of length n; it returns -1 for an empty Array, where Ruby returns nil
+func (r *Random) Sample(n int) int {
+	switch {
+	case n == 0:
+		return -1
+	case n == 1:
+		return 0
+	}
+	return int(r.limited(uint64(n - 1)))
+}
+
+// SampleN() is Array#sample(k, random: r) for an Array of length n; it returns indices of the Array
+func (r *Random) SampleN(n, k int) []int {
+	if k < 0 {
+		panic("rubycompat: negative sample number")
+	}
+	if k > n {
+		k = n
+	}
+	upto := func(max int) int { return int(r.limited(uint64(max - 1))) }
+
+	const small = 10 // length of the index buffer of MRI
+	if k > small {
+		// a partial Fisher-Yates shuffle
+		idx := make([]int, n)
+		for i := range idx {
+			idx[i] = i
+		}
+		for i := 0; i < k; i++ {
+			j := upto(n-i) + i
+			idx[i], idx[j] = idx[j], idx[i]
+		}
+		return idx[:k]
+	}
+
+	rnds := make([]int, k)
+	for i := range rnds {
+		rnds[i] = upto(n - i)
+	}
+	switch k {
+	case 0:
+		return []int{}
+	case 1:
+		return rnds
+	case 2:
+		i, j := rnds[0], rnds[1]
+		if j >= i {
+			j++
+		}
+		return []int{i, j}
+	case 3:
+		i, j, l := rnds[0], rnds[1], rnds[2]
+		lo, hi := j, i
+		if j >= i {
+			lo = i
+			j++
+			hi = j
+		}
+		if l >= lo {
+			l++
+			if l >= hi {
+				l++
+			}
+		}
+		return []int{i, j, l}
+	}
+	// insert each index into a sorted list, skipping the ones already taken
+	sorted := make([]int, 1, k)
+	sorted[0] = rnds[0]
+	result := []int{rnds[0]}
+	for i := 1; i < k; i++ {
+		v := rnds[i]
+		j := 0
+		for ; j < i; j++ {
+			if v < sorted[j] {
+				break
+			}
+			v++
+		}
+		sorted = append(sorted, 0)
+		copy(sorted[j+1:], sorted[j:i])
+		sorted[j] = v
+		result = append(result, v)
+	}
+	return result
+}
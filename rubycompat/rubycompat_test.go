@@ -0,0 +1,162 @@
+package rubycompat_test
+
+import (
+	"math/big"
+	"reflect"
+	"sort"
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/rubycompat"
+)
+
+func TestRandom(t *testing.T) {
+	r := rubycompat.New(mtrand.NewMT32())
+
+	// Random.new(42).rand; a small seed is init_genrand(), same with NumPy
+	r.Seed(42)
+	target1 := []float64{0.3745401188473625, 0.9507143064099162, 0.7319939418114051}
+	for i, v := range target1 {
+		if x := r.Rand(); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+
+	// Random.new(42).rand(100)
+	r.Seed(42)
+	target2 := []int64{51, 92, 14, 71, 60}
+	for i, v := range target2 {
+		if x := r.RandInt(100); x != v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, v, x)
+		}
+	}
+
+	// a negative seed is the same with its absolute value
+	r.Seed(-42)
+	if x := r.Rand(); x != target1[0] {
+		t.Errorf("invalid value for a negative seed: expected %v, actual %v", target1[0], x)
+	}
+
+	// a bignum seed is init_by_array() of its 32-bit words
+	mt := mtrand.NewMT32()
+	seed, _ := new(big.Int).SetString("-123456789abcdeffedcba98", 16)
+	r.SeedBigInt(seed)
+	mt.InitByArray([]uint32{0xfedcba98, 0x89abcdef, 0x01234567})
+	for i := 0; i < 10; i++ {
+		if x, v := r.MT32().GenUint32(), mt.GenUint32(); x != v {
+			t.Errorf("invalid value for iteration %d: expected %x, actual %x", i, v, x)
+		}
+	}
+
+	// the leading word of 1 is removed
+	r.Seed(1<<32 | 5)
+	mt.InitByArray([]uint32{5})
+	for i := 0; i < 10; i++ {
+		if x, v := r.MT32().GenUint32(), mt.GenUint32(); x != v {
+			t.Errorf("invalid value for iteration %d: expected %x, actual %x", i, v, x)
+		}
+	}
+}
+
+func TestRandomRange(t *testing.T) {
+	r := rubycompat.New(mtrand.NewMT32())
+	mt := mtrand.NewMT32()
+
+	// a 64-bit limit takes the upper word first, and retries as soon as the upper word exceeds
+	r.Seed(7)
+	mt.Init(7)
+	limit := uint64(3)<<32 | 0x1234
+	var v uint64
+	for {
+		v = (uint64(mt.GenUint32())&3)<<32 | uint64(mt.GenUint32()) // the upper word never exceeds 3
+		if v <= limit {
+			break
+		}
+	}
+	if x := r.RandInt(int64(limit) + 1); uint64(x) != v {
+		t.Errorf("invalid 64-bit value: expected %x, actual %x", v, x)
+	}
+
+	// rand(beg..end)
+	r.Seed(3)
+	for i := 0; i < 1000; i++ {
+		if x := r.RandRange(-2, 2); x < -2 || x > 2 {
+			t.Errorf("out of range: %v", x)
+		}
+	}
+
+	// rand(Float)
+	r.Seed(42)
+	if x := r.RandFloat(10); x != 10*0.3745401188473625 {
+		t.Errorf("invalid float: %v", x)
+	}
+}
+
+func TestRandomBytes(t *testing.T) {
+	r := rubycompat.New(mtrand.NewMT32())
+	mt := mtrand.NewMT32()
+	r.Seed(1)
+	mt.Init(1)
+	b := r.Bytes(7)
+	v0, v1 := mt.GenUint32(), mt.GenUint32()
+	expected := []byte{byte(v0), byte(v0 >> 8), byte(v0 >> 16), byte(v0 >> 24), byte(v1), byte(v1 >> 8), byte(v1 >> 16)}
+	if !reflect.DeepEqual(b, expected) {
+		t.Errorf("invalid bytes: expected %x, actual %x", expected, b)
+	}
+}
+
+func TestRandomSequences(t *testing.T) {
+	r := rubycompat.New(mtrand.NewMT32())
+
+	// shuffle! swaps from the last element, drawing rand(i) for i = n down to 1
+	r.Seed(42)
+	a := []int{0, 1, 2, 3, 4}
+	r.Shuffle(len(a), func(i, j int) { a[i], a[j] = a[j], a[i] })
+	ref := rubycompat.New(mtrand.NewMT32())
+	ref.Seed(42)
+	b := []int{0, 1, 2, 3, 4}
+	for i := len(b); i > 0; i-- {
+		j := int(ref.RandInt(int64(i)))
+		b[i-1], b[j] = b[j], b[i-1]
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("invalid shuffle: expected %v, actual %v", b, a)
+	}
+
+	// sample picks distinct indices of every size, and a big sample is a partial shuffle
+	for _, n := range []int{1, 2, 5, 20, 100} {
+		for k := 0; k <= n+1; k++ {
+			r.Seed(int64(n*1000 + k))
+			s := r.SampleN(n, k)
+			want := k
+			if want > n {
+				want = n
+			}
+			if len(s) != want {
+				t.Errorf("invalid sample length for (%d, %d): %d", n, k, len(s))
+			}
+			sorted := append([]int(nil), s...)
+			sort.Ints(sorted)
+			for i, v := range sorted {
+				if v < 0 || v >= n || (i > 0 && sorted[i-1] == v) {
+					t.Errorf("invalid sample for (%d, %d): %v", n, k, s)
+					break
+				}
+			}
+		}
+	}
+	if x := r.Sample(0); x != -1 {
+		t.Errorf("invalid sample of an empty array: %v", x)
+	}
+
+	// sample(2) draws rand(n) and rand(n-1)
+	r.Seed(5)
+	ref.Seed(5)
+	i, j := int(ref.RandInt(10)), int(ref.RandInt(9))
+	if j >= i {
+		j++
+	}
+	if s := r.SampleN(10, 2); !reflect.DeepEqual(s, []int{i, j}) {
+		t.Errorf("invalid sample: expected %v, actual %v", []int{i, j}, s)
+	}
+}
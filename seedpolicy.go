@@ -0,0 +1,111 @@
+/*
+	seedpolicy.go
+	policies of Seed(int64) for math/rand
+
+	The same integer seed makes different sequences in different libraries,
+	as they initialize the Mersenne Twister in different ways.
+	A generator created with a SeedPolicy reproduces one of them by Seed(int64).
+*/
+
+package mtrand
+
+// SeedPolicy is the way Seed(int64) initializes a generator
+type SeedPolicy int
+
+const (
+	// Seed() of MT32 is InitByArray() of the lower and upper 32 bits, and Seed() of MT64 is Init()
+	SeedDefault SeedPolicy = iota
+
+	// Init() with the seed truncated to a word; std::mt19937(seed) and std::mt19937_64(seed) of C++
+	SeedInitGenrand
+
+	// InitByArray() of the words of the absolute value of the seed, from the least significant one;
+	// random.seed(seed) of Python for MT32. MT64 uses a single 64-bit word.
+	SeedInitByArray
+
+	// InitBySeedSeq() of a seed sequence of the lower 32 bits of the seed; std::mt19937(std::seed_seq{seed}) of C++,
+	// where seed_seq stores each value of the list truncated to 32 bits
+	SeedSeedSeq
+
+	// Init() with a seed on [0, 2^32-1], which panics for other seeds; numpy.random.seed(seed) for MT32 only
+	SeedNumPyLegacy
+)
+
+// NewMT32WithSeedPolicy() creates a new MT32 of which Seed() follows the policy
+func NewMT32WithSeedPolicy(policy SeedPolicy) *MT32 {
+	mt := NewMT32()
+	mt.policy = policy
+	return mt
+}
+
+// NewMT64WithSeedPolicy() creates a new MT64 of which Seed() follows the policy. SeedNumPyLegacy is not available.
+func NewMT64WithSeedPolicy(policy SeedPolicy) *MT64 {
+	if policy == SeedNumPyLegacy {
+		panic("mtrand: SeedNumPyLegacy is not for MT64")
+	}
+	mt := NewMT64()
+	mt.policy = policy
+	return mt
+}
+
+// mt32.SeedPolicy() returns the policy of Seed()
+func (mt *MT32) SeedPolicy() SeedPolicy {
+	return mt.policy
+}
+
+// mt64.SeedPolicy() returns the policy of Seed()
+func (mt *MT64) SeedPolicy() SeedPolicy {
+	return mt.policy
+}
+
+// 32-bit words of |seed| from the least significant one; one word at least
+func seedWords32(seed int64) []uint32 {
+	u := uint64(seed)
+	if seed < 0 {
+		u = -u
+	}
+	if u>>32 == 0 {
+		return []uint32{uint32(u)}
+	}
+	return []uint32{uint32(u), uint32(u >> 32)}
+}
+
+// seed sequence of a seed; std::seed_seq{seed} keeps the lower 32 bits only
+func seedSeqOf(seed int64) *SeedSeq {
+	return NewSeedSeq(uint32(seed))
+}
+
+// Seed() of MT32 by the policy
+func (mt *MT32) seedByPolicy(seed int64) {
+	switch mt.policy {
+	case SeedInitGenrand:
+		mt.Init(uint32(seed))
+	case SeedInitByArray:
+		mt.InitByArray(seedWords32(seed))
+	case SeedSeedSeq:
+		mt.InitBySeedSeq(seedSeqOf(seed))
+	case SeedNumPyLegacy:
+		if seed < 0 || seed>>32 != 0 {
+			panic("mtrand: seed must be between 0 and 2**32 - 1")
+		}
+		mt.Init(uint32(seed))
+	default:
+		mt.InitByArray([]uint32{uint32(seed & 0xffff_ffff), uint32(seed >> 32)})
+	}
+}
+
+// Seed() of MT64 by the policy
+func (mt *MT64) seedByPolicy(seed int64) {
+	switch mt.policy {
+	case SeedInitByArray:
+		u := uint64(seed)
+		if seed < 0 {
+			u = -u
+		}
+		mt.InitByArray([]uint64{u})
+	case SeedSeedSeq:
+		mt.InitBySeedSeq(seedSeqOf(seed))
+	default: // SeedDefault and SeedInitGenrand
+		mt.Init(uint64(seed))
+	}
+}
@@ -0,0 +1,90 @@
+package mtrand_test
+
+import (
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+)
+
+func TestSeedPolicy(t *testing.T) {
+	// std::mt19937(5489) and std::mt19937_64(5489)
+	mt32 := mtrand.NewMT32WithSeedPolicy(mtrand.SeedInitGenrand)
+	mt32.Seed(5489)
+	if r := mt32.GenUint32(); r != 3499211612 {
+		t.Errorf("invalid value for SeedInitGenrand: expected %v, actual %v", 3499211612, r)
+	}
+	mt64 := mtrand.NewMT64WithSeedPolicy(mtrand.SeedInitGenrand)
+	mt64.Seed(5489)
+	if r := mt64.GenUint64(); r != 14514284786278117030 {
+		t.Errorf("invalid value for SeedInitGenrand: expected %v, actual %v", uint64(14514284786278117030), r)
+	}
+
+	// numpy.random.seed(0); numpy.random.random_sample()
+	mt32 = mtrand.NewMT32WithSeedPolicy(mtrand.SeedNumPyLegacy)
+	mt32.Seed(0)
+	if r := mt32.GenRes53(); r != 0.5488135039273248 {
+		t.Errorf("invalid value for SeedNumPyLegacy: expected %v, actual %v", 0.5488135039273248, r)
+	}
+
+	// random.seed(n) of Python uses the words of |n|
+	ref := mtrand.NewMT32()
+	for _, c := range []struct {
+		seed int64
+		key  []uint32
+	}{
+		{0, []uint32{0}},
+		{42, []uint32{42}},
+		{-42, []uint32{42}},
+		{1 << 40, []uint32{0, 1 << 8}},
+	} {
+		mt32 = mtrand.NewMT32WithSeedPolicy(mtrand.SeedInitByArray)
+		mt32.Seed(c.seed)
+		ref.InitByArray(c.key)
+		for i := 0; i < 10; i++ {
+			if v, r := ref.GenUint32(), mt32.GenUint32(); v != r {
+				t.Fatalf("invalid value for seed %d, iteration %d: expected %v, actual %v", c.seed, i, v, r)
+			}
+		}
+	}
+
+	// std::seed_seq{seed}
+	for _, c := range []struct {
+		seed  int64
+		seeds []uint32
+	}{
+		{7, []uint32{7}},
+		{1<<32 + 5, []uint32{5}},
+		{-1, []uint32{0xffff_ffff}},
+	} {
+		mt32 = mtrand.NewMT32WithSeedPolicy(mtrand.SeedSeedSeq)
+		mt32.Seed(c.seed)
+		ref.InitBySeedSeq(mtrand.NewSeedSeq(c.seeds...))
+		for i := 0; i < 10; i++ {
+			if v, r := ref.GenUint32(), mt32.GenUint32(); v != r {
+				t.Fatalf("invalid value for seed %d, iteration %d: expected %v, actual %v", c.seed, i, v, r)
+			}
+		}
+	}
+
+	// the default policy is unchanged
+	mt32 = mtrand.NewMT32()
+	mt32.Seed(1<<32 + 3)
+	ref.InitByArray([]uint32{3, 1})
+	if v, r := ref.GenUint32(), mt32.GenUint32(); v != r {
+		t.Errorf("invalid value for SeedDefault: expected %v, actual %v", v, r)
+	}
+}
+
+func TestSeedPolicyPanic(t *testing.T) {
+	expectPanic := func(name string, f func()) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s does not panic", name)
+			}
+		}()
+		f()
+	}
+	expectPanic("negative NumPy seed", func() { mtrand.NewMT32WithSeedPolicy(mtrand.SeedNumPyLegacy).Seed(-1) })
+	expectPanic("large NumPy seed", func() { mtrand.NewMT32WithSeedPolicy(mtrand.SeedNumPyLegacy).Seed(1 << 32) })
+	expectPanic("NumPy policy of MT64", func() { mtrand.NewMT64WithSeedPolicy(mtrand.SeedNumPyLegacy) })
+}
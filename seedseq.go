@@ -0,0 +1,144 @@
+/*
+	seedseq.go
+	seed sequence of C++ standard library, std::seed_seq
+
+	A Mersenne Twister initialized by a seed sequence, like std::mt19937(seq) in C++,
+	has a different state from the one initialized by init_by_array().
+	See [rand.util.seedseq] of the C++ standard for the algorithm.
+*/
+
+package mtrand
+
+// Seed sequence that generates initial states like C++ std::seed_seq
+type SeedSeq struct {
+	v []uint32
+}
+
+// NewSeedSeq() creates a seed sequence from seed values, like std::seed_seq{seeds...}
+func NewSeedSeq(seeds ...uint32) *SeedSeq {
+	return &SeedSeq{v: append([]uint32(nil), seeds...)}
+}
+
+// Size() returns the number of seed values
+func (s *SeedSeq) Size() int {
+	return len(s.v)
+}
+
+// Param() returns a copy of the seed values
+func (s *SeedSeq) Param() []uint32 {
+	return append([]uint32(nil), s.v...)
+}
+
+// Generate() fills dst with 32-bit values made from the seeds, like std::seed_seq::generate()
+func (s *SeedSeq) Generate(dst []uint32) {
+	n := len(dst)
+	if n == 0 {
+		return
+	}
+	for i := range dst {
+		dst[i] = 0x8b8b_8b8b
+	}
+
+	sz := len(s.v)
+	var t int
+	switch {
+	case n >= 623:
+		t = 11
+	case n >= 68:
+		t = 7
+	case n >= 39:
+		t = 5
+	case n >= 7:
+		t = 3
+	default:
+		t = (n - 1) / 2
+	}
+	p := (n - t) / 2
+	q := p + t
+	m := sz + 1
+	if n > m {
+		m = n
+	}
+
+	tf := func(x uint32) uint32 { return x ^ (x >> 27) }
+
+	for k := 0; k < m; k++ {
+		r1 := 1664525 * tf(dst[k%n]^dst[(k+p)%n]^dst[(k+n-1)%n])
+		r2 := r1
+		switch {
+		case k == 0:
+			r2 += uint32(sz)
+		case k <= sz:
+			r2 += uint32(k%n) + s.v[k-1]
+		default:
+			r2 += uint32(k % n)
+		}
+		dst[(k+p)%n] += r1
+		dst[(k+q)%n] += r2
+		dst[k%n] = r2
+	}
+	for k := m; k < m+n; k++ {
+		r3 := 1566083941 * tf(dst[k%n]+dst[(k+p)%n]+dst[(k+n-1)%n])
+		r4 := r3 - uint32(k%n)
+		dst[(k+p)%n] ^= r3
+		dst[(k+q)%n] ^= r4
+		dst[k%n] = r4
+	}
+}
+
+// init with a seed sequence, like std::mt19937(seq) of C++
+func (mt *MT32) InitBySeedSeq(seq *SeedSeq) {
+	seq.Generate(mt.mt)
+	// avoid the all-zero state
+	zero := mt.mt[0]&mt32UpperMask == 0
+	for i := 1; zero && i < mt32N; i++ {
+		zero = mt.mt[i] == 0
+	}
+	if zero {
+		mt.mt[0] = mt32UpperMask
+	}
+	mt.i = mt32N
+}
+
+// init with a seed sequence, like std::mt19937_64(seq) of C++
+func (mt *MT64) InitBySeedSeq(seq *SeedSeq) {
+	buf := make([]uint32, 2*mt64NN)
+	seq.Generate(buf)
+	zero := true
+	for i := range mt.mt {
+		mt.mt[i] = uint64(buf[2*i]) | uint64(buf[2*i+1])<<32
+		if i == 0 {
+			zero = mt.mt[0]&mt64UM == 0
+		} else if mt.mt[i] != 0 {
+			zero = false
+		}
+	}
+	if zero {
+		mt.mt[0] = 1 << 63
+	}
+	mt.i = mt64NN
+}
+
+// init with a seed sequence, like std::mersenne_twister_engine(seq) of C++
+func (mt *MTEngine) InitBySeedSeq(seq *SeedSeq) {
+	k := int(mt.p.W+31) / 32 // 32-bit words for a state word
+	buf := make([]uint32, k*mt.p.N)
+	seq.Generate(buf)
+	zero := true
+	for i := range mt.mt {
+		var x uint64
+		for j := k - 1; j >= 0; j-- {
+			x = x<<32 | uint64(buf[k*i+j])
+		}
+		mt.mt[i] = x & mt.wmask
+		if i == 0 {
+			zero = mt.mt[0]&mt.upper == 0
+		} else if mt.mt[i] != 0 {
+			zero = false
+		}
+	}
+	if zero {
+		mt.mt[0] = 1 << (mt.p.W - 1)
+	}
+	mt.i = mt.p.N
+}
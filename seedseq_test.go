@@ -0,0 +1,57 @@
+package mtrand_test
+
+import (
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+)
+
+// compare with outputs of C++ standard library
+func TestSeedSeq(t *testing.T) {
+
+	// std::seed_seq{5}.generate() for 10 values
+	target1 := []uint32{0xda5f2a3e, 0x77ecad8d, 0x763d40db, 0x2a446eb8, 0x418c9e03, 0x3d0fec60, 0x37ae4d96, 0xd5db38a2, 0xa8bb13bc, 0x5410ea51}
+	buf := make([]uint32, len(target1))
+	mtrand.NewSeedSeq(5).Generate(buf)
+	for i, v := range target1 {
+		if buf[i] != v {
+			t.Errorf("invalid value for index %d: expected %x, actual %x", i, v, buf[i])
+		}
+	}
+
+	// std::mt19937 with std::seed_seq{1, 2, 3, 4}
+	target2 := []uint32{2103621173, 3113074417, 3119520880, 1733660703, 1996723807}
+	mt32 := mtrand.NewMT32()
+	mt32.InitBySeedSeq(mtrand.NewSeedSeq(1, 2, 3, 4))
+	e32 := mtrand.NewMTEngine(mtrand.MT19937)
+	e32.InitBySeedSeq(mtrand.NewSeedSeq(1, 2, 3, 4))
+	for i, v := range target2 {
+		r, re := mt32.GenUint32(), e32.GenUint64()
+		if r != v || re != uint64(v) {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d, %d", i, v, r, re)
+		}
+	}
+
+	// std::mt19937 with an empty std::seed_seq
+	target3 := []uint32{2872601305, 4078552948, 3385508327}
+	mt32.InitBySeedSeq(mtrand.NewSeedSeq())
+	for i, v := range target3 {
+		r := mt32.GenUint32()
+		if r != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d", i, v, r)
+		}
+	}
+
+	// std::mt19937_64 with std::seed_seq{0x123, 0x234, 0x345, 0x456}
+	target4 := []uint64{4853207594562173382, 2678050156728426059, 273950074142294480, 13444046447193782176, 14367536099801667209}
+	mt64 := mtrand.NewMT64()
+	mt64.InitBySeedSeq(mtrand.NewSeedSeq(0x123, 0x234, 0x345, 0x456))
+	e64 := mtrand.NewMTEngine(mtrand.MT19937_64)
+	e64.InitBySeedSeq(mtrand.NewSeedSeq(0x123, 0x234, 0x345, 0x456))
+	for i, v := range target4 {
+		r, re := mt64.GenUint64(), e64.GenUint64()
+		if r != v || re != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d, %d", i, v, r, re)
+		}
+	}
+}
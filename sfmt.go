@@ -0,0 +1,237 @@
+/*
+	sfmt.go
+	a translation of SIMD-oriented Fast Mersenne Twister (SFMT) random number generator
+	by Mutsuo Saito and Makoto Matsumoto, "SFMT.c" of SFMT 1.5, with MEXP = 19937
+
+	See http://www.math.sci.hiroshima-u.ac.jp/m-mat/MT/SFMT/index.html
+	for original C source code and tech info.
+*/
+
+package mtrand
+
+const (
+	sfmtMexp = 19937
+	sfmtN    = sfmtMexp/128 + 1 // number of 128-bit words in the state
+	sfmtN32  = sfmtN * 4        // number of 32-bit words in the state
+	sfmtPos1 = 122
+	sfmtSL1  = 18
+	sfmtSL2  = 1 // in bytes
+	sfmtSR1  = 11
+	sfmtSR2  = 1 // in bytes
+)
+
+var (
+	sfmtMsk    = [4]uint32{0xdfff_ffef, 0xddfe_cb7f, 0xbffa_ffff, 0xbfff_fff6}
+	sfmtParity = [4]uint32{0x0000_0001, 0x0000_0000, 0x0000_0000, 0x13c9_e684}
+)
+
+// SIMD-oriented Fast Mersenne Twister random generator, SFMT-19937
+type SFMT struct {
+	state []uint32 // the array for the state vector; 128-bit word k is state[4k:4k+4]
+	i     int      // index of 32-bit words. if i==sfmtN32+1, then state[] is not initialized
+}
+
+// NewSFMT() creates a new SFMT-19937 random generator
+func NewSFMT() *SFMT {
+	return &SFMT{state: make([]uint32, sfmtN32), i: sfmtN32 + 1}
+}
+
+// 128-bit shifts by bytes; a 128-bit word is little endian 32-bit words
+func sfmtLShift128(in []uint32, shift uint) (out [4]uint32) {
+	th := uint64(in[3])<<32 | uint64(in[2])
+	tl := uint64(in[1])<<32 | uint64(in[0])
+	oh := th<<(shift*8) | tl>>(64-shift*8)
+	ol := tl << (shift * 8)
+	return [4]uint32{uint32(ol), uint32(ol >> 32), uint32(oh), uint32(oh >> 32)}
+}
+
+func sfmtRShift128(in []uint32, shift uint) (out [4]uint32) {
+	th := uint64(in[3])<<32 | uint64(in[2])
+	tl := uint64(in[1])<<32 | uint64(in[0])
+	oh := th >> (shift * 8)
+	ol := tl>>(shift*8) | th<<(64-shift*8)
+	return [4]uint32{uint32(ol), uint32(ol >> 32), uint32(oh), uint32(oh >> 32)}
+}
+
+// the recursion formula; r may be the same with a
+func sfmtDoRecursion(r, a, b, c, d []uint32) {
+	x := sfmtLShift128(a, sfmtSL2)
+	y := sfmtRShift128(c, sfmtSR2)
+	for k := 0; k < 4; k++ {
+		r[k] = a[k] ^ x[k] ^ ((b[k] >> sfmtSR1) & sfmtMsk[k]) ^ y[k] ^ (d[k] << sfmtSL1)
+	}
+}
+
+// w128() returns 128-bit word k of the state
+func (s *SFMT) w128(k int) []uint32 {
+	return s.state[4*k : 4*k+4]
+}
+
+// fill the state array with new values
+func (s *SFMT) genRandAll() {
+	r1, r2 := s.w128(sfmtN-2), s.w128(sfmtN-1)
+	i := 0
+	for ; i < sfmtN-sfmtPos1; i++ {
+		sfmtDoRecursion(s.w128(i), s.w128(i), s.w128(i+sfmtPos1), r1, r2)
+		r1, r2 = r2, s.w128(i)
+	}
+	for ; i < sfmtN; i++ {
+		sfmtDoRecursion(s.w128(i), s.w128(i), s.w128(i+sfmtPos1-sfmtN), r1, r2)
+		r1, r2 = r2, s.w128(i)
+	}
+}
+
+// make sure that the period is 2^MEXP-1
+func (s *SFMT) periodCertification() {
+	var inner uint32
+	for i := 0; i < 4; i++ {
+		inner ^= s.state[i] & sfmtParity[i]
+	}
+	for i := 16; i > 0; i >>= 1 {
+		inner ^= inner >> i
+	}
+	if inner&1 == 1 {
+		return
+	}
+	for i := 0; i < 4; i++ {
+		work := uint32(1)
+		for j := 0; j < 32; j++ {
+			if work&sfmtParity[i] != 0 {
+				s.state[i] ^= work
+				return
+			}
+			work <<= 1
+		}
+	}
+}
+
+// init the state with a seed
+func (s *SFMT) Init(seed uint32) {
+	st := s.state
+	st[0] = seed
+	for i := 1; i < sfmtN32; i++ {
+		st[i] = 1812433253*(st[i-1]^(st[i-1]>>30)) + uint32(i)
+	}
+	s.i = sfmtN32
+	s.periodCertification()
+}
+
+func sfmtFunc1(x uint32) uint32 { return (x ^ (x >> 27)) * 1664525 }
+func sfmtFunc2(x uint32) uint32 { return (x ^ (x >> 27)) * 1566083941 }
+
+// init with an array
+func (s *SFMT) InitByArray(key []uint32) {
+	const (
+		size = sfmtN32
+		lag  = 11 // for size >= 623
+		mid  = (size - lag) / 2
+	)
+	st := s.state
+	for i := range st {
+		st[i] = 0x8b8b_8b8b
+	}
+
+	keylen := len(key)
+	count := size
+	if keylen+1 > size {
+		count = keylen + 1
+	}
+
+	r := sfmtFunc1(st[0] ^ st[mid] ^ st[size-1])
+	st[mid] += r
+	r += uint32(keylen)
+	st[mid+lag] += r
+	st[0] = r
+	count--
+
+	i, j := 1, 0
+	for ; j < count && j < keylen; j++ {
+		r = sfmtFunc1(st[i] ^ st[(i+mid)%size] ^ st[(i+size-1)%size])
+		st[(i+mid)%size] += r
+		r += key[j] + uint32(i)
+		st[(i+mid+lag)%size] += r
+		st[i] = r
+		i = (i + 1) % size
+	}
+	for ; j < count; j++ {
+		r = sfmtFunc1(st[i] ^ st[(i+mid)%size] ^ st[(i+size-1)%size])
+		st[(i+mid)%size] += r
+		r += uint32(i)
+		st[(i+mid+lag)%size] += r
+		st[i] = r
+		i = (i + 1) % size
+	}
+	for j = 0; j < size; j++ {
+		r = sfmtFunc2(st[i] + st[(i+mid)%size] + st[(i+size-1)%size])
+		st[(i+mid)%size] ^= r
+		r -= uint32(i)
+		st[(i+mid+lag)%size] ^= r
+		st[i] = r
+		i = (i + 1) % size
+	}
+
+	s.i = sfmtN32
+	s.periodCertification()
+}
+
+// generates a random number on [0,0xffffffff]-interval
+func (s *SFMT) GenUint32() uint32 {
+	if s.i >= sfmtN32 {
+		if s.i == sfmtN32+1 { // if Init() has not been called,
+			s.Init(5489) // a default initial seed is used
+		}
+		s.genRandAll()
+		s.i = 0
+	}
+	r := s.state[s.i]
+	s.i++
+	return r
+}
+
+// generates a random number on [0, 2^64-1]-interval.
+// Like the assertion of the original, it panics after an odd number of 32-bit outputs since the last block,
+// as a 64-bit number must take an aligned pair of words.
+func (s *SFMT) GenUint64() uint64 {
+	if s.i&1 != 0 && s.i < sfmtN32 {
+		panic("mtrand: GenUint64() after an odd number of 32-bit outputs")
+	}
+	if s.i >= sfmtN32 {
+		if s.i == sfmtN32+1 {
+			s.Init(5489)
+		}
+		s.genRandAll()
+		s.i = 0
+	}
+	r := uint64(s.state[s.i+1])<<32 | uint64(s.state[s.i])
+	s.i += 2
+	return r
+}
+
+// generates a random number on [0,0x7fffffff]-interval
+func (s *SFMT) GenInt31() int32 {
+	return int32(s.GenUint32() >> 1)
+}
+
+// generates a random number on [0,1]-real-interval
+func (s *SFMT) GenReal1() float64 {
+	// divided by 2^32-1
+	return float64(s.GenUint32()) * (1.0 / 4294967295.0)
+}
+
+// generates a random number on [0,1)-real-interval
+func (s *SFMT) GenReal2() float64 {
+	// divided by 2^32
+	return float64(s.GenUint32()) * (1.0 / 4294967296.0)
+}
+
+// generates a random number on (0,1)-real-interval
+func (s *SFMT) GenReal3() float64 {
+	// divided by 2^32
+	return (float64(s.GenUint32()) + 0.5) * (1.0 / 4294967296.0)
+}
+
+// generates a random number on [0,1) with 53-bit resolution
+func (s *SFMT) GenRes53() float64 {
+	a, b := s.GenUint32()>>5, s.GenUint32()>>6
+	return (float64(a)*67108864.0 + float64(b)) * (1.0 / 9007199254740992.0)
+}
@@ -0,0 +1,97 @@
+package mtrand_test
+
+import (
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+)
+
+// compare generated numbers with the output from original program
+func TestSFMT(t *testing.T) {
+
+	// first outputs of init_gen_rand(1234) in "SFMT.19937.out.txt"
+	target1 := []uint32{3440181298, 1564997079, 1510669302, 2930277156, 1452439940}
+	s := mtrand.NewSFMT()
+	s.Init(1234)
+	for i, v := range target1 {
+		r := s.GenUint32()
+		if r != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d", i, v, r)
+		}
+	}
+
+	// init by array
+	target2 := []uint32{2920711183, 3885745737, 3501893680, 856470934, 1421864068}
+	s.InitByArray([]uint32{0x1234, 0x5678, 0x9abc, 0xdef0})
+	for i, v := range target2 {
+		r := s.GenUint32()
+		if r != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d", i, v, r)
+		}
+	}
+}
+
+// a 64-bit number is an aligned pair of 32-bit words
+func TestSFMTUint64(t *testing.T) {
+	s, s32 := mtrand.NewSFMT(), mtrand.NewSFMT()
+	s.Init(1234)
+	s32.Init(1234)
+	for i := 0; i < 1000; i++ {
+		v := uint64(s32.GenUint32())
+		v |= uint64(s32.GenUint32()) << 32
+		if r := s.GenUint64(); r != v {
+			t.Fatalf("invalid value for iteration %d: expected %d, actual %d", i, v, r)
+		}
+	}
+
+	s.GenUint32()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("no panic for GenUint64() after an odd number of outputs")
+		}
+	}()
+	s.GenUint64()
+}
+
+// jumping must be the same as generating numbers, like "test-jump.c" of the original
+func TestSFMTJump(t *testing.T) {
+	steps := []struct {
+		mul  uint64
+		base uint
+		skip int // numbers taken before jump
+	}{
+		{1, 0, 0}, {1, 0, 8}, {155, 0, 0}, {157, 0, 4}, {10000, 0, 100}, {3, 10, 0}, {1, 14, 620},
+	}
+	for _, c := range steps {
+		s1, s2 := mtrand.NewSFMT(), mtrand.NewSFMT()
+		s1.Init(1234)
+		s2.Init(1234)
+		for i := 0; i < c.skip; i++ {
+			s1.GenUint32()
+			s2.GenUint32()
+		}
+		n := 4 * (int(c.mul) << c.base)
+		for i := 0; i < n; i++ {
+			s1.GenUint32()
+		}
+		s2.JumpSteps(c.mul, c.base)
+		for i := 0; i < 1000; i++ {
+			v, r := s1.GenUint32(), s2.GenUint32()
+			if r != v {
+				t.Errorf("jump %d*2^%d: invalid value for iteration %d: expected %d, actual %d", c.mul, c.base, i, v, r)
+				break
+			}
+		}
+	}
+
+	// a jump is the sum of its parts
+	s1, s2 := mtrand.NewSFMT(), mtrand.NewSFMT()
+	s1.JumpSteps(3, 62)
+	s1.JumpSteps(1, 62)
+	s2.JumpSteps(1, 64)
+	for i := 0; i < 100; i++ {
+		if v, r := s1.GenUint32(), s2.GenUint32(); r != v {
+			t.Fatalf("invalid value for iteration %d: expected %d, actual %d", i, v, r)
+		}
+	}
+}
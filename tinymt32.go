@@ -0,0 +1,200 @@
+/*
+	tinymt32.go
+	a translation of Tiny Mersenne Twister (TinyMT) 32-bit random number generator
+	by Mutsuo Saito and Makoto Matsumoto, "tinymt32.c" and "tinymt32.h"
+
+	See http://www.math.sci.hiroshima-u.ac.jp/m-mat/MT/TINYMT/index.html
+	for original C source code and tech info.
+*/
+
+package mtrand
+
+const (
+	tinymt32Sh0     = 1
+	tinymt32Sh1     = 10
+	tinymt32Sh8     = 8
+	tinymt32Mask    = 0x7fff_ffff
+	tinymt32MinLoop = 8
+	tinymt32PreLoop = 8
+)
+
+// Parameter set of a TinyMT32 generator.
+// Generators with different parameter sets generate independent streams.
+type TinyMT32Params struct {
+	Mat1 uint32
+	Mat2 uint32
+	Tmat uint32
+}
+
+// The parameter set used in the check program of the original distribution
+var TinyMT32DefaultParams = TinyMT32Params{Mat1: 0x8f70_11ee, Mat2: 0xfc78_ff1f, Tmat: 0x3793_fdff}
+
+// TinyMT 32-bit random generator, with period 2^127-1
+type TinyMT32 struct {
+	status [4]uint32
+	p      TinyMT32Params
+	init   bool // true if the state is initialized
+}
+
+// NewTinyMT32() creates a new TinyMT32 random generator with given parameters
+func NewTinyMT32(p TinyMT32Params) *TinyMT32 {
+	return &TinyMT32{p: p}
+}
+
+// Params() returns the parameter set of the generator
+func (mt *TinyMT32) Params() TinyMT32Params {
+	return mt.p
+}
+
+func (mt *TinyMT32) nextState() {
+	y := mt.status[3]
+	x := (mt.status[0] & tinymt32Mask) ^ mt.status[1] ^ mt.status[2]
+	x ^= (x << tinymt32Sh0)
+	y ^= (y >> tinymt32Sh0) ^ x
+	mt.status[0] = mt.status[1]
+	mt.status[1] = mt.status[2]
+	mt.status[2] = x ^ (y << tinymt32Sh1)
+	mt.status[3] = y
+	if y&1 != 0 {
+		mt.status[1] ^= mt.p.Mat1
+		mt.status[2] ^= mt.p.Mat2
+	}
+}
+
+func (mt *TinyMT32) temper() uint32 {
+	t0 := mt.status[3]
+	t1 := mt.status[0] + (mt.status[2] >> tinymt32Sh8)
+	t0 ^= t1
+	if t1&1 != 0 {
+		t0 ^= mt.p.Tmat
+	}
+	return t0
+}
+
+// the GF(2)-linear version of temper(), used for equidistribution check
+func (mt *TinyMT32) temperLinear() uint32 {
+	t0 := mt.status[3]
+	t1 := mt.status[0] ^ (mt.status[2] >> tinymt32Sh8)
+	t0 ^= t1
+	if t1&1 != 0 {
+		t0 ^= mt.p.Tmat
+	}
+	return t0
+}
+
+// avoid the all-zero state
+func (mt *TinyMT32) periodCertification() {
+	if mt.status[0]&tinymt32Mask == 0 && mt.status[1] == 0 && mt.status[2] == 0 && mt.status[3] == 0 {
+		mt.status = [4]uint32{'T', 'I', 'N', 'Y'}
+	}
+}
+
+// init the state with a seed
+func (mt *TinyMT32) Init(seed uint32) {
+	mt.status = [4]uint32{seed, mt.p.Mat1, mt.p.Mat2, mt.p.Tmat}
+	for i := uint32(1); i < tinymt32MinLoop; i++ {
+		mt.status[i&3] ^= i + 1812433253*(mt.status[(i-1)&3]^(mt.status[(i-1)&3]>>30))
+	}
+	mt.periodCertification()
+	for i := 0; i < tinymt32PreLoop; i++ {
+		mt.nextState()
+	}
+	mt.init = true
+}
+
+func tinymt32IniFunc1(x uint32) uint32 { return (x ^ (x >> 27)) * 1664525 }
+func tinymt32IniFunc2(x uint32) uint32 { return (x ^ (x >> 27)) * 1566083941 }
+
+// init with an array
+func (mt *TinyMT32) InitByArray(key []uint32) {
+	const (
+		lag  = 1
+		mid  = 1
+		size = 4
+	)
+	st := &mt.status
+	*st = [4]uint32{0, mt.p.Mat1, mt.p.Mat2, mt.p.Tmat}
+
+	keylen := len(key)
+	count := tinymt32MinLoop
+	if keylen+1 > tinymt32MinLoop {
+		count = keylen + 1
+	}
+
+	r := tinymt32IniFunc1(st[0] ^ st[mid%size] ^ st[(size-1)%size])
+	st[mid%size] += r
+	r += uint32(keylen)
+	st[(mid+lag)%size] += r
+	st[0] = r
+	count--
+
+	i, j := 1, 0
+	for ; j < count && j < keylen; j++ {
+		r = tinymt32IniFunc1(st[i%size] ^ st[(i+mid)%size] ^ st[(i+size-1)%size])
+		st[(i+mid)%size] += r
+		r += key[j] + uint32(i)
+		st[(i+mid+lag)%size] += r
+		st[i%size] = r
+		i = (i + 1) % size
+	}
+	for ; j < count; j++ {
+		r = tinymt32IniFunc1(st[i%size] ^ st[(i+mid)%size] ^ st[(i+size-1)%size])
+		st[(i+mid)%size] += r
+		r += uint32(i)
+		st[(i+mid+lag)%size] += r
+		st[i%size] = r
+		i = (i + 1) % size
+	}
+	for j = 0; j < size; j++ {
+		r = tinymt32IniFunc2(st[i%size] + st[(i+mid)%size] + st[(i+size-1)%size])
+		st[(i+mid)%size] ^= r
+		r -= uint32(i)
+		st[(i+mid+lag)%size] ^= r
+		st[i%size] = r
+		i = (i + 1) % size
+	}
+
+	mt.periodCertification()
+	for i := 0; i < tinymt32PreLoop; i++ {
+		mt.nextState()
+	}
+	mt.init = true
+}
+
+// generates a random number on [0,0xffffffff]-interval
+func (mt *TinyMT32) GenUint32() uint32 {
+	if !mt.init { // if the state has not been initialized,
+		mt.Init(5489) // a default initial seed is used
+	}
+	mt.nextState()
+	return mt.temper()
+}
+
+// generates a random number on [0,0x7fffffff]-interval
+func (mt *TinyMT32) GenInt31() int32 {
+	return int32(mt.GenUint32() >> 1)
+}
+
+// generates a random number on [0,1]-real-interval
+func (mt *TinyMT32) GenReal1() float64 {
+	// divided by 2^32-1
+	return float64(mt.GenUint32()) * (1.0 / 4294967295.0)
+}
+
+// generates a random number on [0,1)-real-interval
+func (mt *TinyMT32) GenReal2() float64 {
+	// divided by 2^32
+	return float64(mt.GenUint32()) * (1.0 / 4294967296.0)
+}
+
+// generates a random number on (0,1)-real-interval
+func (mt *TinyMT32) GenReal3() float64 {
+	// divided by 2^32
+	return (float64(mt.GenUint32()) + 0.5) * (1.0 / 4294967296.0)
+}
+
+// generates a random number on [0,1) with 53-bit resolution
+func (mt *TinyMT32) GenRes53() float64 {
+	a, b := mt.GenUint32()>>5, mt.GenUint32()>>6
+	return (float64(a)*67108864.0 + float64(b)) * (1.0 / 9007199254740992.0)
+}
@@ -0,0 +1,61 @@
+package mtrand_test
+
+import (
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+)
+
+// compare generated numbers with the output from original program
+func TestTinyMT32(t *testing.T) {
+
+	// first outputs of "tinymt32 0x8f7011ee 0xfc78ff1f 0x3793fdff seed = 1"
+	target1 := []uint32{2545341989, 981918433, 3715302833, 2387538352, 3591001365}
+	mt := mtrand.NewTinyMT32(mtrand.TinyMT32DefaultParams)
+	mt.Init(1)
+	for i, v := range target1 {
+		r := mt.GenUint32()
+		if r != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d", i, v, r)
+		}
+	}
+
+	// init by array
+	target2 := []uint32{56890874, 895028026, 626205227, 491377950, 2651386131}
+	mt.InitByArray([]uint32{1})
+	for i, v := range target2 {
+		r := mt.GenUint32()
+		if r != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d", i, v, r)
+		}
+	}
+}
+
+// compare a checked parameter set with the published table of tinymt32dc
+func TestTinyMT32DC(t *testing.T) {
+	target := "d8524022ed8dff4a8dcc50c798faba43,0,0,0x8f7011ee,0xfc78ff1f,0x3793fdff,63,0"
+	info, ok := mtrand.CheckTinyMT32Params(mtrand.TinyMT32DefaultParams)
+	if !ok {
+		t.Fatalf("published parameter set is rejected")
+	}
+	if info.String() != target {
+		t.Errorf("invalid parameter info: expected %s, actual %s", target, info)
+	}
+
+	// a parameter set with a reducible characteristic polynomial
+	if _, ok := mtrand.CheckTinyMT32Params(mtrand.TinyMT32Params{}); ok {
+		t.Errorf("invalid parameter set is accepted")
+	}
+
+	// search must be deterministic, and the result must pass the check
+	p1 := mtrand.SearchTinyMT32Params(1, 3, 4)
+	p2 := mtrand.SearchTinyMT32Params(1, 3, 4)
+	if p1 != p2 {
+		t.Errorf("search is not deterministic: %v, %v", p1, p2)
+	}
+	info, ok = mtrand.CheckTinyMT32Params(p1.TinyMT32Params)
+	info.ID = p1.ID
+	if !ok || info != p1 {
+		t.Errorf("searched parameter set does not pass the check: %v, %v", p1, info)
+	}
+}
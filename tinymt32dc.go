@@ -0,0 +1,238 @@
+/*
+	tinymt32dc.go
+	parameter search for TinyMT32, like "tinymt32dc" of TinyMTDC
+	by Mutsuo Saito and Makoto Matsumoto
+
+	See http://www.math.sci.hiroshima-u.ac.jp/m-mat/MT/TINYMT/index.html
+	for original C++ source code and tech info.
+
+	A parameter set is valid if the characteristic polynomial of the state transition is primitive.
+	The degree of the polynomial is 127 and 2^127-1 is a prime, so it is enough to check irreducibility.
+	The quality of the tempering is measured by delta, the total dimension defect of equidistribution
+	of the GF(2)-linearized output, as in the original.
+
+	The candidates are drawn from MT32 initialized by (seed, id),
+	so a search is reproducible but its results are not the same with the published tables,
+	which were made by the original C++ program. The candidate generator of the original is not ported:
+	its source was not available, and a brute-force test of plain MT19937 seedings did not reproduce the published rows.
+	CheckTinyMT32Params() gives the same characteristic polynomial, weight and delta with the tables for a published parameter set.
+*/
+
+package mtrand
+
+import (
+	"fmt"
+)
+
+const (
+	tinymt32Mexp = 127 // degree of the characteristic polynomial
+)
+
+// Properties of a TinyMT32 parameter set
+type TinyMT32ParamInfo struct {
+	TinyMT32Params
+	ID       uint32    // id given to the search
+	CharPoly [2]uint64 // characteristic polynomial; bit i is the coefficient of x^i
+	Weight   int       // number of nonzero terms of the characteristic polynomial
+	Delta    int       // total dimension defect of equidistribution; 0 is the best
+}
+
+// String() gives the parameter set in the output format of tinymt32dc:
+// "characteristic,type,id,mat1,mat2,tmat,weight,delta"
+func (p TinyMT32ParamInfo) String() string {
+	return fmt.Sprintf("%016x%016x,%d,%d,0x%08x,0x%08x,0x%08x,%d,%d",
+		p.CharPoly[1], p.CharPoly[0], 0, p.ID, p.Mat1, p.Mat2, p.Tmat, p.Weight, p.Delta)
+}
+
+// a polynomial over GF(2) of degree < 128; bit i is the coefficient of x^i
+type f2poly128 [2]uint64
+
+func (p f2poly128) bit(i int) uint64 {
+	return p[i/64] >> (i % 64) & 1
+}
+
+// multiply a by x, modulo m of degree 127
+func (a *f2poly128) mulXMod127(m f2poly128) {
+	a[1] = a[1]<<1 | a[0]>>63
+	a[0] <<= 1
+	if a[1]>>63 != 0 {
+		a[0] ^= m[0]
+		a[1] ^= m[1]
+	}
+}
+
+// multiply a and b, modulo m of degree 127
+func f2poly128MulMod127(a, b, m f2poly128) (r f2poly128) {
+	for i := tinymt32Mexp - 1; i >= 0; i-- {
+		r.mulXMod127(m)
+		if b.bit(i) != 0 {
+			r[0] ^= a[0]
+			r[1] ^= a[1]
+		}
+	}
+	return
+}
+
+// tinymt32CharPoly() computes the characteristic polynomial of the state transition.
+// ok is false if the degree of the minimal polynomial is less than 127.
+func tinymt32CharPoly(p TinyMT32Params) (poly f2poly128, ok bool) {
+	mt := NewTinyMT32(p)
+	mt.status = [4]uint32{0, 0, 0, 1}
+	s := make([]uint8, 2*tinymt32Mexp)
+	for i := range s {
+		mt.nextState()
+		s[i] = uint8(mt.status[3] & 1)
+	}
+	c, l := berlekampMassey(s)
+	if l != tinymt32Mexp {
+		return poly, false
+	}
+	// the characteristic polynomial is the reciprocal of the connection polynomial
+	for i := 0; i <= l; i++ {
+		if c[i] != 0 {
+			e := l - i
+			poly[e/64] |= 1 << (e % 64)
+		}
+	}
+	return poly, true
+}
+
+// f2poly128IsIrreducible127() checks irreducibility of a polynomial of prime degree 127
+func f2poly128IsIrreducible127(m f2poly128) bool {
+	// no factor of degree 1
+	weight := f2poly128Weight(m)
+	if m.bit(0) == 0 || weight%2 == 0 {
+		return false
+	}
+	// x^(2^127) == x (mod m) means that all factors have degrees dividing 127
+	x := f2poly128{2, 0}
+	r := x
+	for i := 0; i < tinymt32Mexp; i++ {
+		r = f2poly128MulMod127(r, r, m)
+	}
+	return r == x
+}
+
+func f2poly128Weight(p f2poly128) (w int) {
+	for i := 0; i < 128; i++ {
+		w += int(p.bit(i))
+	}
+	return
+}
+
+// tinymt32Delta() computes the total dimension defect of equidistribution of v-bit accuracy, for v = 1 to 32
+func tinymt32Delta(p TinyMT32Params) int {
+	// basis of the state space; the MSB of status[0] is not a part of the state
+	basis := make([][4]uint32, 0, tinymt32Mexp)
+	for w := 0; w < 4; w++ {
+		for b := 0; b < 32; b++ {
+			if w == 0 && b == 31 {
+				continue
+			}
+			var st [4]uint32
+			st[w] = 1 << b
+			basis = append(basis, st)
+		}
+	}
+
+	mt := NewTinyMT32(p)
+	delta := 0
+	vec := make([]f2poly128, len(basis))
+	for v := 1; v <= 32; v++ {
+		kmax := tinymt32Mexp / v
+		k := kmax
+		for ; k > 0; k-- {
+			// output vectors of the basis: upper v bits of k outputs
+			for i, st := range basis {
+				mt.status = st
+				var o f2poly128
+				for j := 0; j < k; j++ {
+					mt.nextState()
+					u := uint64(mt.temperLinear() >> (32 - v))
+					for b := 0; b < v; b++ {
+						pos := j*v + b
+						o[pos/64] |= (u >> b & 1) << (pos % 64)
+					}
+				}
+				vec[i] = o
+			}
+			if f2rank(vec, v*k) == v*k {
+				break
+			}
+		}
+		delta += kmax - k
+	}
+	return delta
+}
+
+// rank of vectors of n bits; the content of vec is destroyed
+func f2rank(vec []f2poly128, n int) int {
+	rank := 0
+	for col := 0; col < n && rank < len(vec); col++ {
+		pivot := -1
+		for i := rank; i < len(vec); i++ {
+			if vec[i].bit(col) != 0 {
+				pivot = i
+				break
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+		vec[rank], vec[pivot] = vec[pivot], vec[rank]
+		for i := rank + 1; i < len(vec); i++ {
+			if vec[i].bit(col) != 0 {
+				vec[i][0] ^= vec[rank][0]
+				vec[i][1] ^= vec[rank][1]
+			}
+		}
+		rank++
+	}
+	return rank
+}
+
+// CheckTinyMT32Params() computes the properties of a parameter set.
+// ok is false if the parameter set does not give the maximal period 2^127-1.
+func CheckTinyMT32Params(p TinyMT32Params) (info TinyMT32ParamInfo, ok bool) {
+	info.TinyMT32Params = p
+	poly, ok := tinymt32CharPoly(p)
+	if !ok || !f2poly128IsIrreducible127(poly) {
+		return info, false
+	}
+	info.CharPoly = poly
+	info.Weight = f2poly128Weight(poly)
+	info.Delta = tinymt32Delta(p)
+	return info, true
+}
+
+// SearchTinyMT32Params() searches a parameter set of maximal period for the given id.
+// The search is deterministic; the same seed and id give the same parameter set.
+// The candidates are not drawn in the way of the original tinymt32dc, so the results are not the rows of the published tables;
+// use CheckTinyMT32Params() to verify a published row.
+// For each valid (mat1, mat2), up to tmatTry tmat candidates are tested and the one with the smallest delta is taken.
+func SearchTinyMT32Params(seed, id uint32, tmatTry int) TinyMT32ParamInfo {
+	if tmatTry < 1 {
+		tmatTry = 1
+	}
+	rng := NewMT32()
+	rng.InitByArray([]uint32{seed, id})
+	for {
+		p := TinyMT32Params{Mat1: rng.GenUint32(), Mat2: rng.GenUint32()}
+		poly, ok := tinymt32CharPoly(p)
+		if !ok || !f2poly128IsIrreducible127(poly) {
+			continue
+		}
+		best := TinyMT32ParamInfo{ID: id, CharPoly: poly, Weight: f2poly128Weight(poly), Delta: -1}
+		for i := 0; i < tmatTry; i++ {
+			p.Tmat = rng.GenUint32()
+			d := tinymt32Delta(p)
+			if best.Delta < 0 || d < best.Delta {
+				best.TinyMT32Params, best.Delta = p, d
+			}
+			if d == 0 {
+				break
+			}
+		}
+		return best
+	}
+}
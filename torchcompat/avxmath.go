@@ -0,0 +1,153 @@
+/*
+	avxmath.go
+	The vectorized Box-Muller kernel of PyTorch on AVX2,
+	with log256_ps() and sincos256_ps() of avx_mathfun.h evaluated one lane at a time
+*/
+
+package torchcompat
+
+import (
+	"math"
+)
+
+// constants of avx_mathfun.h, which are the ones of Cephes single precision functions
+const (
+	cephesSQRTHF = float32(0.707106781186547524)
+	cephesLogP0  = float32(7.0376836292e-2)
+	cephesLogP1  = float32(-1.1514610310e-1)
+	cephesLogP2  = float32(1.1676998740e-1)
+	cephesLogP3  = float32(-1.2420140846e-1)
+	cephesLogP4  = float32(1.4249322787e-1)
+	cephesLogP5  = float32(-1.6668057665e-1)
+	cephesLogP6  = float32(2.0000714765e-1)
+	cephesLogP7  = float32(-2.4999993993e-1)
+	cephesLogP8  = float32(3.3333331174e-1)
+	cephesLogQ1  = float32(-2.12194440e-4)
+	cephesLogQ2  = float32(0.693359375)
+
+	cephesFOPI     = float32(1.27323954473516) // 4 / pi
+	cephesMinusDP1 = float32(-0.78515625)
+	cephesMinusDP2 = float32(-2.4187564849853515625e-4)
+	cephesMinusDP3 = float32(-3.77489497744594108e-8)
+	sincofP0       = float32(-1.9515295891e-4)
+	sincofP1       = float32(8.3321608736e-3)
+	sincofP2       = float32(-1.6666654611e-1)
+	coscofP0       = float32(2.443315711809948e-5)
+	coscofP1       = float32(-1.388731625493765e-3)
+	coscofP2       = float32(4.166664568298827e-2)
+)
+
+// log256_ps() for a lane
+func log256(x float32) float32 {
+	if x <= 0 {
+		return float32(math.NaN())
+	}
+	b := math.Float32bits(x)
+	if b < 0x0080_0000 {
+		b = 0x0080_0000 // cut off denormals
+	}
+	e := float32(int32(b>>23)-0x7f) + 1
+	x = math.Float32frombits(b&^0x7f80_0000 | 0x3f00_0000) // mantissa on [0.5, 1)
+
+	var tmp float32
+	if x < cephesSQRTHF {
+		tmp = x
+		e -= 1
+	}
+	x = float32(x-1) + tmp
+
+	z := x * x
+	y := cephesLogP0
+	for _, p := range [...]float32{cephesLogP1, cephesLogP2, cephesLogP3, cephesLogP4,
+		cephesLogP5, cephesLogP6, cephesLogP7, cephesLogP8} {
+		y = float32(y*x) + p
+	}
+	y = float32(float32(y*x) * z)
+	y = y + float32(e*cephesLogQ1)
+	y = y - float32(z*0.5)
+	x = x + y
+	x = x + float32(e*cephesLogQ2)
+	return x
+}
+
+// sincos256_ps() for a lane
+func sincos256(x float32) (s, c float32) {
+	signSin := math.Float32bits(x) & 0x8000_0000
+	x = float32(math.Abs(float64(x)))
+
+	j := int32(x * cephesFOPI) // truncation
+	j = (j + 1) &^ 1
+	y := float32(j)
+
+	swapSignSin := uint32(j&4) << 29
+	polySin := j&2 == 0 // the sine polynomial is used for sine
+
+	// extended precision modular arithmetic
+	x = x + float32(y*cephesMinusDP1)
+	x = x + float32(y*cephesMinusDP2)
+	x = x + float32(y*cephesMinusDP3)
+
+	signCos := uint32(^(j-2)&4) << 29
+	signSin ^= swapSignSin
+
+	z := x * x
+	yc := float32(coscofP0*z) + coscofP1
+	yc = float32(yc*z) + coscofP2
+	yc = float32(float32(yc*z) * z)
+	yc = yc - float32(z*0.5)
+	yc = yc + 1
+
+	ys := float32(sincofP0*z) + sincofP1
+	ys = float32(ys*z) + sincofP2
+	ys = float32(float32(ys*z) * x)
+	ys = ys + x
+
+	if polySin {
+		s, c = ys, yc
+	} else {
+		s, c = yc, ys
+	}
+	s = math.Float32frombits(math.Float32bits(s) ^ signSin)
+	c = math.Float32frombits(math.Float32bits(c) ^ signCos)
+	return
+}
+
+// fma32() is _mm256_fmadd_ps() for a lane; a*b+c rounded once
+func fma32(a, b, c float32) float32 {
+	p := float64(float64(a) * float64(b)) // exact
+	s := p + float64(c)
+	bb := s - p
+	err := (p - (s - bb)) + (float64(c) - bb) // s + err is exact
+	r := float32(s)
+	if err == 0 || float64(r) == s {
+		return r
+	}
+	// s is not exact; it matters only when s is halfway between two floats
+	var n float32
+	if s > float64(r) {
+		n = math.Nextafter32(r, float32(math.Inf(1)))
+	} else {
+		n = math.Nextafter32(r, float32(math.Inf(-1)))
+	}
+	if (float64(r)+float64(n))/2 != s {
+		return r
+	}
+	if (err > 0) == (n > r) {
+		return n
+	}
+	return r
+}
+
+// normal_fill_16_AVX2()
+func normalFill16AVX2(data []float32, mean, std float32) {
+	const twoPi = float32(2.0 * math.Pi)
+	for j := 0; j < 8; j++ {
+		u1 := 1 - data[j]
+		u2 := data[j+8]
+		radius := float32(math.Sqrt(float64(-2 * log256(u1))))
+		theta := twoPi * u2
+		sin, cos := sincos256(theta)
+		data[j] = fma32(radius*cos, std, mean)
+		data[j+8] = fma32(radius*sin, std, mean)
+	}
+}
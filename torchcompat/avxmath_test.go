@@ -0,0 +1,39 @@
+package torchcompat
+
+import (
+	"math"
+	"testing"
+)
+
+// the Cephes polynomials are accurate in a few ulps
+func TestAVXMath(t *testing.T) {
+	for i := 1; i < 1<<24; i += 97 {
+		x := float32(i) / (1 << 24)
+		if l, e := log256(x), math.Log(float64(x)); math.Abs(float64(l)-e) > 1e-6*math.Max(1, math.Abs(e)) {
+			t.Fatalf("invalid log for %v: expected %v, actual %v", x, e, l)
+		}
+		th := x * float32(2*math.Pi)
+		s, c := sincos256(th)
+		if math.Abs(float64(s)-math.Sin(float64(th))) > 1e-6 || math.Abs(float64(c)-math.Cos(float64(th))) > 1e-6 {
+			t.Fatalf("invalid sincos for %v: expected %v %v, actual %v %v", th, math.Sin(float64(th)), math.Cos(float64(th)), s, c)
+		}
+	}
+}
+
+// fma32() is rounded once; a double sum on the midpoint of two floats is rounded by the error of the sum
+func TestFMA32(t *testing.T) {
+	a := float32(1.0 / (1 << 12) * (1 + 1.0/(1<<23)))
+	b := float32(1.0 / (1 << 12) * (1 - 1.0/(1<<23)))
+	c := float32(1 + 1.0/(1<<23))
+	// c - a*b = 1 + 2^-24 + 2^-70, which is 1 + 2^-24 in double
+	if r := fma32(a, -b, c); r != c {
+		t.Errorf("invalid fma32: expected %v, actual %v", c, r)
+	}
+	if r := fma32(-a, -b, -c); r != -c {
+		t.Errorf("invalid fma32: expected %v, actual %v", -c, r)
+	}
+	// 1 + 2^-24 - 2^-70 is rounded down
+	if r := fma32(a, b, 1); r != 1 {
+		t.Errorf("invalid fma32: expected %v, actual %v", 1, r)
+	}
+}
@@ -0,0 +1,297 @@
+/*
+Package torchcompat reproduces the CPU random number generator of PyTorch on a Mersenne Twister of mtrand.
+
+torch.Generator on CPU is MT19937, the same generator with mtrand.MT32,
+and Generator in this package generates the same tensors with the CPU kernels of PyTorch for the same seed.
+Tensors are contiguous 1-d slices here; a tensor of any shape is filled in the same order.
+
+	g := torchcompat.New(mtrand.NewMT32(), torchcompat.CapabilityAVX2)
+	g.ManualSeed(0)        // torch.manual_seed(0)
+	x := g.Rand(3)         // torch.rand(3)
+	z := g.Randn(16)       // torch.randn(16)
+	n := g.Randint(0, 10, 5) // torch.randint(0, 10, (5,))
+
+torch.randn() of 16 or more floats uses vectorized kernels on CPUs with AVX2, which give slightly different numbers
+from the default kernel, so the CPU capability of the PyTorch build must be chosen with New().
+
+PyTorch was not available to capture values in full precision, so the floats are tested against
+the numbers PyTorch prints, in 4 decimal places, which do not tell the two kernels apart.
+The default kernel of 16 numbers is also tested bit by bit against normal_fill_16() of PyTorch compiled with gcc;
+it uses logf(), sinf() and cosf() of glibc without FMA, which glibc selects on the CPUs without AVX2 and FMA.
+glibc on CPUs with FMA uses the FMA variants of the functions, which differ in rare last bits.
+Randint() is compared with PyTorch exactly for a small range, and Randperm() is tested as a permutation only.
+
+Like math/rand, invalid arguments cause a panic, where PyTorch raises RuntimeError.
+*/
+package torchcompat
+
+import (
+	"math"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/internal/libm"
+)
+
+// CPU capability of the kernels of PyTorch
+type Capability int
+
+const (
+	CapabilityDefault Capability = iota // scalar kernels, for CPUs without AVX2
+	CapabilityAVX2                      // AVX2 and AVX512 kernels
+)
+
+// Generator is a random number generator with the algorithms of torch.Generator on CPU
+type Generator struct {
+	mt         *mtrand.MT32
+	capability Capability
+
+	nextDouble    float64 // saved number of normal_distribution<double>
+	hasNextDouble bool
+}
+
+// New() creates a Generator on mt. The state of mt is used as is until ManualSeed() is called.
+func New(mt *mtrand.MT32, capability Capability) *Generator {
+	return &Generator{mt: mt, capability: capability}
+}
+
+// MT32() returns the underlying generator
+func (g *Generator) MT32() *mtrand.MT32 {
+	return g.mt
+}
+
+//
+// seeding
+//
+
+// ManualSeed() is torch.manual_seed(seed) or Generator.manual_seed(seed); the lower 32 bits are used
+func (g *Generator) ManualSeed(seed uint64) {
+	g.mt.Init(uint32(seed))
+	g.nextDouble, g.hasNextDouble = 0, false
+}
+
+//
+// raw numbers
+//
+
+// Random() is CPUGeneratorImpl::random(); a 32-bit number
+func (g *Generator) Random() uint32 {
+	return g.mt.GenUint32()
+}
+
+// Random64() is CPUGeneratorImpl::random64(); the first 32-bit number is the upper half
+func (g *Generator) Random64() uint64 {
+	hi := uint64(g.mt.GenUint32())
+	return hi<<32 | uint64(g.mt.GenUint32())
+}
+
+//
+// uniform
+//
+
+// uniform_real_distribution<float>(0, 1); a float on [0, 1) with 24-bit resolution
+func (g *Generator) uniform32() float32 {
+	return float32(g.Random()&(1<<24-1)) * (1.0 / (1 << 24))
+}
+
+// uniform_real_distribution<double>(0, 1); a float on [0, 1) with 53-bit resolution
+func (g *Generator) uniform64() float64 {
+	return float64(float64(g.Random64()&(1<<53-1)) * (1.0 / (1 << 53)))
+}
+
+// Uniform() is torch.empty(n).uniform_(from, to)
+func (g *Generator) Uniform(n int, from, to float32) []float32 {
+	out := make([]float32, n)
+	for i := range out {
+		out[i] = float32(g.uniform32()*(to-from)) + from
+	}
+	return out
+}
+
+// UniformDouble() is torch.empty(n, dtype=torch.float64).uniform_(from, to)
+func (g *Generator) UniformDouble(n int, from, to float64) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = float64(g.uniform64()*(to-from)) + from
+	}
+	return out
+}
+
+// Rand() is torch.rand(n)
+func (g *Generator) Rand(n int) []float32 {
+	return g.Uniform(n, 0, 1)
+}
+
+// RandDouble() is torch.rand(n, dtype=torch.float64)
+func (g *Generator) RandDouble(n int) []float64 {
+	return g.UniformDouble(n, 0, 1)
+}
+
+//
+// normal
+//
+
+// normal_distribution<double>(mean, std) by Box-Muller method; the second number is kept for the next call
+func (g *Generator) normalDist(mean, std float64) float64 {
+	if g.hasNextDouble {
+		g.hasNextDouble = false
+		return float64(g.nextDouble*std) + mean
+	}
+	u1 := g.uniform64()
+	u2 := g.uniform64()
+	r := math.Sqrt(-2.0 * libm.Log(1.0-u2))
+	theta := 2.0 * math.Pi * u1
+	g.nextDouble, g.hasNextDouble = r*libm.Sin(theta), true
+	return float64(float64(r*libm.Cos(theta))*std) + mean
+}
+
+// Normal() is torch.empty(n).normal_(mean, std)
+func (g *Generator) Normal(n int, mean, std float32) []float32 {
+	out := make([]float32, n)
+	if n < 16 {
+		for i := range out {
+			out[i] = float32(g.normalDist(float64(mean), float64(std)))
+		}
+		return out
+	}
+	fill16 := normalFill16
+	if g.capability == CapabilityAVX2 {
+		fill16 = normalFill16AVX2
+	}
+	for i := range out {
+		out[i] = g.uniform32()
+	}
+	for i := 0; i < n-15; i += 16 {
+		fill16(out[i:i+16], mean, std)
+	}
+	if n%16 != 0 {
+		// recompute the last 16 numbers
+		last := out[n-16:]
+		for i := range last {
+			last[i] = g.uniform32()
+		}
+		fill16(last, mean, std)
+	}
+	return out
+}
+
+// NormalDouble() is torch.empty(n, dtype=torch.float64).normal_(mean, std)
+func (g *Generator) NormalDouble(n int, mean, std float64) []float64 {
+	out := make([]float64, n)
+	if n < 16 {
+		for i := range out {
+			out[i] = g.normalDist(mean, std)
+		}
+		return out
+	}
+	for i := range out {
+		out[i] = g.uniform64()
+	}
+	for i := 0; i < n-15; i += 16 {
+		normalFill16Double(out[i:i+16], mean, std)
+	}
+	if n%16 != 0 {
+		last := out[n-16:]
+		for i := range last {
+			last[i] = g.uniform64()
+		}
+		normalFill16Double(last, mean, std)
+	}
+	return out
+}
+
+// Randn() is torch.randn(n)
+func (g *Generator) Randn(n int) []float32 {
+	return g.Normal(n, 0, 1)
+}
+
+// RandnDouble() is torch.randn(n, dtype=torch.float64)
+func (g *Generator) RandnDouble(n int) []float64 {
+	return g.NormalDouble(n, 0, 1)
+}
+
+// normal_fill_16(); Box-Muller transform of 16 uniform numbers, paired as data[j] and data[j+8].
+// logf(), sinf() and cosf() are the ones of glibc without FMA, as on the CPUs of the scalar kernel.
+func normalFill16(data []float32, mean, std float32) {
+	for j := 0; j < 8; j++ {
+		u1 := 1 - data[j]
+		u2 := data[j+8]
+		radius := float32(math.Sqrt(float64(-2 * libm.Logf(u1))))
+		theta := float32(2.0 * math.Pi * float64(u2))
+		data[j] = float32(float32(radius*libm.Cosf(theta))*std) + mean
+		data[j+8] = float32(float32(radius*libm.Sinf(theta))*std) + mean
+	}
+}
+
+func normalFill16Double(data []float64, mean, std float64) {
+	for j := 0; j < 8; j++ {
+		u1 := 1 - data[j]
+		u2 := data[j+8]
+		radius := math.Sqrt(-2 * libm.Log(u1))
+		theta := 2.0 * math.Pi * u2
+		data[j] = float64(float64(radius*libm.Cos(theta))*std) + mean
+		data[j+8] = float64(float64(radius*libm.Sin(theta))*std) + mean
+	}
+}
+
+//
+// integers
+//
+
+// Randint() is torch.randint(low, high, (n,)); the number is taken modulo the range
+func (g *Generator) Randint(low, high int64, n int) []int64 {
+	if low >= high {
+		panic("torchcompat: random_ expects 'from' to be less than 'to'")
+	}
+	rng := uint64(high) - uint64(low)
+	out := make([]int64, n)
+	for i := range out {
+		var v uint64
+		if rng >= 1<<32 {
+			v = g.Random64()
+		} else {
+			v = uint64(g.Random())
+		}
+		out[i] = int64(v%rng) + low
+	}
+	return out
+}
+
+// Randperm() is torch.randperm(n)
+func (g *Generator) Randperm(n int) []int64 {
+	if n < 0 {
+		panic("torchcompat: n must be non-negative")
+	}
+	r := make([]int64, n)
+	if n < math.MaxUint32/20 {
+		for i := range r {
+			r[i] = int64(i)
+		}
+		for i := 0; i < n-1; i++ {
+			z := int(g.Random() % uint32(n-i))
+			r[i], r[z+i] = r[z+i], r[i]
+		}
+		return r
+	}
+	// the inside-out Fisher-Yates shuffle for large n
+	for i := range r {
+		z := int(g.Random64() % uint64(i+1))
+		r[i] = r[z]
+		r[z] = int64(i)
+	}
+	return r
+}
+
+// Bernoulli() is torch.empty(n).bernoulli_(p) by the default kernel; 1 if a uniform number is less than p.
+// PyTorch builds with MKL use MKL's generator on Intel CPUs instead, which is not reproduced here.
+func (g *Generator) Bernoulli(n int, p float64) []float32 {
+	if !(p >= 0 && p <= 1) {
+		panic("torchcompat: bernoulli_ expects p to be in [0, 1]")
+	}
+	out := make([]float32, n)
+	for i := range out {
+		if g.uniform64() < p {
+			out[i] = 1
+		}
+	}
+	return out
+}
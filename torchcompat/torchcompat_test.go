@@ -0,0 +1,177 @@
+package torchcompat_test
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+	"github.com/mixcode/golib-mtrand/torchcompat"
+)
+
+// compare with the numbers printed by PyTorch, in 4 decimal places
+func checkPrinted(t *testing.T, name string, expected []float64, actual []float32) {
+	t.Helper()
+	for i, v := range expected {
+		if math.Abs(float64(actual[i])-v) > 0.5e-4 {
+			t.Errorf("%s: invalid value for iteration %d: expected %v, actual %v", name, i, v, actual[i])
+		}
+	}
+}
+
+func TestPrinted(t *testing.T) {
+	for _, c := range []torchcompat.Capability{torchcompat.CapabilityDefault, torchcompat.CapabilityAVX2} {
+		g := torchcompat.New(mtrand.NewMT32(), c)
+
+		g.ManualSeed(0)
+		checkPrinted(t, "rand", []float64{0.4963, 0.7682, 0.0885}, g.Rand(3))
+		g.ManualSeed(42)
+		checkPrinted(t, "rand", []float64{0.8823, 0.9150, 0.3829}, g.Rand(3))
+
+		// torch.randn(2, 3); the scalar kernel with the saved second number
+		g.ManualSeed(0)
+		checkPrinted(t, "randn", []float64{1.5410, -0.2934, -2.1788, 0.5684, -1.0845, -1.3986}, g.Randn(6))
+
+		// torch.randn(4, 4); the kernel of 16 numbers
+		g.ManualSeed(0)
+		checkPrinted(t, "randn", []float64{
+			-1.1258, -1.1524, -0.2506, -0.4339, 0.8487, 0.6920, -0.3160, -2.1152,
+			0.3223, -1.2633, 0.3500, 0.3081, 0.1198, 1.2377, 1.1168, -0.2473,
+		}, g.Randn(16))
+
+		g.ManualSeed(0)
+		expected := []int64{4, 9, 3, 0, 3}
+		for i, v := range g.Randint(0, 10, 5) {
+			if v != expected[i] {
+				t.Errorf("randint: invalid value for iteration %d: expected %v, actual %v", i, expected[i], v)
+			}
+		}
+	}
+}
+
+// numbers of size%16 != 0 are filled by 16, and the last 16 are recomputed by new uniform numbers
+func TestRandnTail(t *testing.T) {
+	g1 := torchcompat.New(mtrand.NewMT32(), torchcompat.CapabilityDefault)
+	g2 := torchcompat.New(mtrand.NewMT32(), torchcompat.CapabilityDefault)
+	g1.ManualSeed(1)
+	g2.ManualSeed(1)
+	a := g1.Randn(20)
+	b := g2.Randn(16)
+	for i := 0; i < 4; i++ {
+		if a[i] != b[i] {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, b[i], a[i])
+		}
+	}
+	g2.Rand(4) // the uniform numbers overwritten
+	c := g2.Randn(16)
+	for i := 0; i < 16; i++ {
+		if a[4+i] != c[i] {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", 4+i, c[i], a[4+i])
+		}
+	}
+}
+
+// torch.randn(16000) on the scalar kernel, compared with normal_fill_16() of PyTorch compiled by gcc on glibc 2.36;
+// the numbers sampled, and an FNV-1a hash of the bits of all numbers
+func TestRandnDefault(t *testing.T) {
+	g := torchcompat.New(mtrand.NewMT32(), torchcompat.CapabilityDefault)
+	g.ManualSeed(0)
+	a := g.Randn(16000)
+	target := []struct {
+		i int
+		v float32
+	}{
+		{0, -0x1.20370ap+0}, {1, -0x1.270114p+0}, {2, -0x1.0097acp-2}, {3, -0x1.bc4abcp-2},
+		{8, 0x1.4a027p-2}, {9, -0x1.4369e8p+0}, {1234, -0x1.6a0d3cp-1}, {5678, 0x1.3bac1ep+0},
+		{9999, -0x1.3eea1p-2}, {15999, 0x1.2e0442p-2},
+	}
+	for _, c := range target {
+		if a[c.i] != c.v {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", c.i, c.v, a[c.i])
+		}
+	}
+	h := uint64(14695981039346656037)
+	for _, v := range a {
+		h = (h ^ uint64(math.Float32bits(v))) * 1099511628211
+	}
+	if h != 0xb733adb4b5d48ea1 {
+		t.Errorf("invalid hash of the numbers: expected %#x, actual %#x", uint64(0xb733adb4b5d48ea1), h)
+	}
+}
+
+// the AVX2 kernel of 16 numbers and the default kernel differ in the last bits, but not more
+func TestRandnCapability(t *testing.T) {
+	g1 := torchcompat.New(mtrand.NewMT32(), torchcompat.CapabilityDefault)
+	g2 := torchcompat.New(mtrand.NewMT32(), torchcompat.CapabilityAVX2)
+	g1.ManualSeed(0)
+	g2.ManualSeed(0)
+	a, b := g1.Randn(1600), g2.Randn(1600)
+	diff := 0
+	for i := range a {
+		if a[i] != b[i] {
+			diff++
+		}
+		if math.Abs(float64(a[i]-b[i])) > 1e-5*math.Max(1, math.Abs(float64(a[i]))) {
+			t.Errorf("invalid value for iteration %d: expected %v, actual %v", i, a[i], b[i])
+		}
+	}
+	if diff == 0 {
+		t.Errorf("the kernels give the same numbers")
+	}
+}
+
+func TestRandpermRandint(t *testing.T) {
+	g := torchcompat.New(mtrand.NewMT32(), torchcompat.CapabilityDefault)
+	g.ManualSeed(7)
+	for n := 0; n < 100; n++ {
+		p := g.Randperm(n)
+		s := append([]int64(nil), p...)
+		sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+		for i, v := range s {
+			if v != int64(i) {
+				t.Fatalf("randperm(%d) is not a permutation: %v", n, p)
+			}
+		}
+	}
+
+	// ranges of 2^32 and wider use 64-bit numbers
+	mt := mtrand.NewMT32()
+	mt.Init(7)
+	g = torchcompat.New(mtrand.NewMT32(), torchcompat.CapabilityDefault)
+	g.ManualSeed(7)
+	for i, v := range g.Randint(-5, 1<<32-5, 100) {
+		hi := uint64(mt.GenUint32())
+		e := int64((hi<<32|uint64(mt.GenUint32()))%(1<<32)) - 5
+		if v != e {
+			t.Fatalf("invalid value for iteration %d: expected %v, actual %v", i, e, v)
+		}
+	}
+}
+
+// sample moments
+func TestMoments(t *testing.T) {
+	const n = 1000000
+	for _, c := range []torchcompat.Capability{torchcompat.CapabilityDefault, torchcompat.CapabilityAVX2} {
+		g := torchcompat.New(mtrand.NewMT32(), c)
+		g.ManualSeed(3)
+		sum, sum2 := 0.0, 0.0
+		for _, x := range g.Normal(n, 1, 2) {
+			sum += float64(x)
+			sum2 += float64(x) * float64(x)
+		}
+		mean := sum / n
+		variance := sum2/n - mean*mean
+		if math.Abs(mean-1) > 0.01 || math.Abs(variance-4) > 0.04 {
+			t.Errorf("invalid normal distribution: mean %v, variance %v", mean, variance)
+		}
+	}
+
+	g := torchcompat.New(mtrand.NewMT32(), torchcompat.CapabilityDefault)
+	ones := 0.0
+	for _, x := range g.Bernoulli(n, 0.3) {
+		ones += float64(x)
+	}
+	if math.Abs(ones/n-0.3) > 0.003 {
+		t.Errorf("invalid bernoulli distribution: %v", ones/n)
+	}
+}
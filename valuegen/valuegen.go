@@ -0,0 +1,416 @@
+/*
+Package valuegen fills Go values with random data of a Mersenne Twister of mtrand, for tests and fixtures.
+
+Values are made by reflection from an MT64 initialized by a seed,
+so a failing test can be replayed from the seed it printed.
+
+	seed := uint64(time.Now().UnixNano())
+	t.Logf("valuegen seed: %d", seed)
+	g := valuegen.New(seed, nil)
+	var v MyStruct
+	g.Fill(&v)
+
+With testing/quick, QuickValues() of a Gen makes Values of quick.Config.
+
+A type makes its own values by implementing Generator, or by a function given to Register().
+Fields of a struct are controlled by tags:
+
+	type T struct {
+		Name  string  `valuegen:"maxlen=5"`      // at most 5 runes
+		Age   int     `valuegen:"min=0,max=120"` // on [0, 120]; elements of slices, arrays and pointers as well
+		Next  *T      `valuegen:"nil=0.8"`       // nil at the probability 0.8
+		Cache []byte  `valuegen:"-"`             // left zero
+	}
+
+Interfaces, functions and channels are left zero, unless a Generator or a registered function makes them.
+Unexported fields of structs are always left zero, even if their types have a Generator or a registered function,
+as reflection cannot set them.
+Like math/rand, invalid tags and arguments cause a panic.
+*/
+package valuegen
+
+import (
+	"math"
+	"math/bits"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+)
+
+// Generator is implemented by types that make their own random values. Generate() is called on a new zero value.
+type Generator interface {
+	Generate(g *Gen)
+}
+
+var generatorType = reflect.TypeOf((*Generator)(nil)).Elem()
+
+// Config is the limits of generated values
+type Config struct {
+	MaxLen   int     // maximum length of strings, slices and maps
+	MaxDepth int     // pointers, slices and maps nested deeper than this are nil
+	NilProb  float64 // probability that a pointer, a slice or a map is nil
+	Alphabet string  // runes of strings
+}
+
+// DefaultConfig is used when New() is given no Config
+var DefaultConfig = Config{
+	MaxLen:   8,
+	MaxDepth: 4,
+	NilProb:  0.1,
+	Alphabet: " !\"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\]^_`abcdefghijklmnopqrstuvwxyz{|}~",
+}
+
+// Gen is a generator of random values
+type Gen struct {
+	mt     *mtrand.MT64
+	seed   uint64
+	cfg    Config
+	runes  []rune
+	custom map[reflect.Type]func(g *Gen) reflect.Value
+	depth  int // nesting of pointers, slices and maps being made
+}
+
+// New() creates a Gen of which MT64 is initialized by Init(seed). cfg may be nil for DefaultConfig.
+func New(seed uint64, cfg *Config) *Gen {
+	c := DefaultConfig
+	if cfg != nil {
+		c = *cfg
+	}
+	if c.MaxLen < 0 || c.MaxDepth < 0 || !(c.NilProb >= 0 && c.NilProb <= 1) || c.Alphabet == "" {
+		panic("valuegen: invalid config")
+	}
+	mt := mtrand.NewMT64()
+	mt.Init(seed)
+	return &Gen{
+		mt:     mt,
+		seed:   seed,
+		cfg:    c,
+		runes:  []rune(c.Alphabet),
+		custom: map[reflect.Type]func(g *Gen) reflect.Value{},
+	}
+}
+
+// Seed() returns the seed given to New()
+func (g *Gen) Seed() uint64 {
+	return g.seed
+}
+
+// MT64() returns the underlying generator
+func (g *Gen) MT64() *mtrand.MT64 {
+	return g.mt
+}
+
+// Register() makes f the generator of the type t, for the types that cannot implement Generator.
+// f must return a value assignable to t.
+func (g *Gen) Register(t reflect.Type, f func(g *Gen) reflect.Value) {
+	g.custom[t] = f
+}
+
+//
+// numbers
+//
+
+// Uint64() is a 64-bit number
+func (g *Gen) Uint64() uint64 {
+	return g.mt.GenUint64()
+}
+
+// an integer on [0, n] by masked rejection
+func (g *Gen) uintn(n uint64) uint64 {
+	mask := uint64(1)<<uint(bits.Len64(n)) - 1
+	if n == math.MaxUint64 {
+		mask = math.MaxUint64
+	}
+	for {
+		if v := g.Uint64() & mask; v <= n {
+			return v
+		}
+	}
+}
+
+// Intn() is an integer on [0, n)
+func (g *Gen) Intn(n int) int {
+	if n <= 0 {
+		panic("valuegen: invalid argument to Intn")
+	}
+	return int(g.uintn(uint64(n - 1)))
+}
+
+// Float64() is a float on [0, 1)
+func (g *Gen) Float64() float64 {
+	return g.mt.GenReal2()
+}
+
+// Bool() is true or false
+func (g *Gen) Bool() bool {
+	return g.Uint64()>>63 != 0
+}
+
+// true at the probability p
+func (g *Gen) chance(p float64) bool {
+	return p > 0 && g.Float64() < p
+}
+
+//
+// values
+//
+
+// Fill() sets random data to the value pointed by ptr
+func (g *Gen) Fill(ptr interface{}) {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		panic("valuegen: Fill() needs a non-nil pointer")
+	}
+	g.fill(v.Elem(), nil)
+}
+
+// Value() makes a random value of the type t
+func (g *Gen) Value(t reflect.Type) reflect.Value {
+	v := reflect.New(t).Elem()
+	g.fill(v, nil)
+	return v
+}
+
+// QuickValues() makes a function for Values of quick.Config, which makes the arguments of f
+func (g *Gen) QuickValues(f interface{}) func(args []reflect.Value, r *rand.Rand) {
+	ft := reflect.TypeOf(f)
+	if ft == nil || ft.Kind() != reflect.Func {
+		panic("valuegen: QuickValues() needs a function")
+	}
+	return func(args []reflect.Value, _ *rand.Rand) {
+		for i := range args {
+			args[i] = g.Value(ft.In(i))
+		}
+	}
+}
+
+// options of a struct field
+type fieldTag struct {
+	maxLen   int // -1 if not given
+	nilProb  float64
+	hasNil   bool
+	min, max string // bounds of numbers, given together
+}
+
+// parseTag() parses the tag of a field. It returns nil for "-".
+func parseTag(tag string, name string) *fieldTag {
+	ft := &fieldTag{maxLen: -1}
+	if tag == "" {
+		return ft
+	}
+	if tag == "-" {
+		return nil
+	}
+	var err error
+	for _, opt := range strings.Split(tag, ",") {
+		kv := strings.SplitN(opt, "=", 2)
+		if len(kv) != 2 {
+			panic("valuegen: invalid tag of " + name + ": " + opt)
+		}
+		switch kv[0] {
+		case "maxlen":
+			ft.maxLen, err = strconv.Atoi(kv[1])
+			if err != nil || ft.maxLen < 0 {
+				panic("valuegen: invalid maxlen of " + name)
+			}
+		case "nil":
+			ft.nilProb, err = strconv.ParseFloat(kv[1], 64)
+			if err != nil || !(ft.nilProb >= 0 && ft.nilProb <= 1) {
+				panic("valuegen: invalid nil of " + name)
+			}
+			ft.hasNil = true
+		case "min":
+			ft.min = kv[1]
+		case "max":
+			ft.max = kv[1]
+		default:
+			panic("valuegen: unknown tag of " + name + ": " + kv[0])
+		}
+	}
+	if (ft.min == "") != (ft.max == "") {
+		panic("valuegen: min and max of " + name + " must be given together")
+	}
+	return ft
+}
+
+// the options passed to the elements of a slice, an array or a pointer; only the bounds of numbers
+func (ft *fieldTag) elem() *fieldTag {
+	if ft == nil || ft.min == "" {
+		return nil
+	}
+	return &fieldTag{maxLen: -1, min: ft.min, max: ft.max}
+}
+
+// whether a pointer, a slice or a map is nil
+func (g *Gen) isNil(ft *fieldTag) bool {
+	if g.depth >= g.cfg.MaxDepth {
+		return true
+	}
+	p := g.cfg.NilProb
+	if ft != nil && ft.hasNil {
+		p = ft.nilProb
+	}
+	return g.chance(p)
+}
+
+// length of a string, a slice or a map
+func (g *Gen) length(ft *fieldTag) int {
+	n := g.cfg.MaxLen
+	if ft != nil && ft.maxLen >= 0 {
+		n = ft.maxLen
+	}
+	return g.Intn(n + 1)
+}
+
+// a float on [-max, max], like testing/quick
+func (g *Gen) float(max float64) float64 {
+	f := g.Float64() * max
+	if g.Bool() {
+		f = -f
+	}
+	return f
+}
+
+func (g *Gen) fill(v reflect.Value, ft *fieldTag) {
+	t := v.Type()
+	if f, ok := g.custom[t]; ok {
+		v.Set(f(g))
+		return
+	}
+	if reflect.PtrTo(t).Implements(generatorType) {
+		p := reflect.New(t)
+		p.Interface().(Generator).Generate(g)
+		v.Set(p.Elem())
+		return
+	}
+	ranged := ft != nil && ft.min != ""
+
+	switch t.Kind() {
+	case reflect.Bool:
+		v.SetBool(g.Bool())
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !ranged {
+			v.SetInt(int64(g.Uint64()))
+			return
+		}
+		lo, err1 := strconv.ParseInt(ft.min, 0, 64)
+		hi, err2 := strconv.ParseInt(ft.max, 0, 64)
+		if err1 != nil || err2 != nil || lo > hi || v.OverflowInt(lo) || v.OverflowInt(hi) {
+			panic("valuegen: invalid min or max for " + t.String())
+		}
+		v.SetInt(lo + int64(g.uintn(uint64(hi)-uint64(lo))))
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if !ranged {
+			v.SetUint(g.Uint64())
+			return
+		}
+		lo, err1 := strconv.ParseUint(ft.min, 0, 64)
+		hi, err2 := strconv.ParseUint(ft.max, 0, 64)
+		if err1 != nil || err2 != nil || lo > hi || v.OverflowUint(lo) || v.OverflowUint(hi) {
+			panic("valuegen: invalid min or max for " + t.String())
+		}
+		v.SetUint(lo + g.uintn(hi-lo))
+
+	case reflect.Float32, reflect.Float64:
+		if !ranged {
+			if t.Kind() == reflect.Float32 {
+				v.SetFloat(g.float(math.MaxFloat32))
+			} else {
+				v.SetFloat(g.float(math.MaxFloat64))
+			}
+			return
+		}
+		lo, err1 := strconv.ParseFloat(ft.min, 64)
+		hi, err2 := strconv.ParseFloat(ft.max, 64)
+		if err1 != nil || err2 != nil || !(lo <= hi) || math.IsInf(lo, 0) || math.IsInf(hi, 0) ||
+			v.OverflowFloat(lo) || v.OverflowFloat(hi) {
+			panic("valuegen: invalid min or max for " + t.String())
+		}
+		// hi-lo overflows for wide ranges, so the bounds are weighted instead
+		u := g.Float64()
+		x := float64(lo*(1-u)) + float64(hi*u)
+		if x > hi {
+			x = hi
+		} else if x < lo {
+			x = lo
+		}
+		v.SetFloat(x)
+
+	case reflect.Complex64:
+		v.SetComplex(complex(g.float(math.MaxFloat32), g.float(math.MaxFloat32)))
+
+	case reflect.Complex128:
+		v.SetComplex(complex(g.float(math.MaxFloat64), g.float(math.MaxFloat64)))
+
+	case reflect.String:
+		r := make([]rune, g.length(ft))
+		for i := range r {
+			r[i] = g.runes[g.Intn(len(g.runes))]
+		}
+		v.SetString(string(r))
+
+	case reflect.Slice:
+		if g.isNil(ft) {
+			v.Set(reflect.Zero(t))
+			return
+		}
+		n := g.length(ft)
+		s := reflect.MakeSlice(t, n, n)
+		g.depth++
+		for i := 0; i < n; i++ {
+			g.fill(s.Index(i), ft.elem())
+		}
+		g.depth--
+		v.Set(s)
+
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			g.fill(v.Index(i), ft.elem())
+		}
+
+	case reflect.Map:
+		if g.isNil(ft) {
+			v.Set(reflect.Zero(t))
+			return
+		}
+		n := g.length(ft)
+		m := reflect.MakeMapWithSize(t, n)
+		g.depth++
+		for i := 0; i < n; i++ {
+			key := g.Value(t.Key())
+			m.SetMapIndex(key, g.Value(t.Elem()))
+		}
+		g.depth--
+		v.Set(m)
+
+	case reflect.Ptr:
+		if g.isNil(ft) {
+			v.Set(reflect.Zero(t))
+			return
+		}
+		p := reflect.New(t.Elem())
+		g.depth++
+		g.fill(p.Elem(), ft.elem())
+		g.depth--
+		v.Set(p)
+
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue // unexported
+			}
+			if fft := parseTag(f.Tag.Get("valuegen"), t.String()+"."+f.Name); fft != nil {
+				g.fill(v.Field(i), fft)
+			}
+		}
+
+	default:
+		// interfaces, functions, channels and unsafe pointers are left zero
+	}
+}
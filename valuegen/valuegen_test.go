@@ -0,0 +1,217 @@
+package valuegen_test
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/mixcode/golib-mtrand/valuegen"
+)
+
+type node struct {
+	Name     string
+	Value    int32
+	Ratio    float64
+	Flags    [3]bool
+	Tags     []string
+	Attr     map[string]uint16
+	Next     *node
+	Any      interface{}
+	internal int
+}
+
+// same seeds make same values
+func TestDeterministic(t *testing.T) {
+	for seed := uint64(0); seed < 20; seed++ {
+		var a, b node
+		valuegen.New(seed, nil).Fill(&a)
+		valuegen.New(seed, nil).Fill(&b)
+		if !reflect.DeepEqual(a, b) {
+			t.Errorf("different values for seed %d: %+v, %+v", seed, a, b)
+		}
+		if a.Any != nil || a.internal != 0 {
+			t.Errorf("interface or unexported field is set for seed %d", seed)
+		}
+	}
+
+	var a, b node
+	valuegen.New(1, nil).Fill(&a)
+	valuegen.New(2, nil).Fill(&b)
+	if reflect.DeepEqual(a, b) {
+		t.Errorf("same values for different seeds")
+	}
+}
+
+type tagged struct {
+	Small  int8      `valuegen:"min=-3,max=3"`
+	Unit   float32   `valuegen:"min=0,max=1"`
+	Digits []uint    `valuegen:"min=0,max=9,maxlen=4,nil=0"`
+	Short  string    `valuegen:"maxlen=2"`
+	Never  *int      `valuegen:"nil=1"`
+	Always *int      `valuegen:"nil=0,min=10,max=20"`
+	Skip   []float64 `valuegen:"-"`
+}
+
+func TestTags(t *testing.T) {
+	g := valuegen.New(uint64(time.Now().UnixNano()), nil)
+	t.Logf("seed: %d", g.Seed())
+	for i := 0; i < 1000; i++ {
+		var v tagged
+		g.Fill(&v)
+		if v.Small < -3 || v.Small > 3 {
+			t.Fatalf("Small out of range: %d", v.Small)
+		}
+		if v.Unit < 0 || v.Unit > 1 {
+			t.Fatalf("Unit out of range: %v", v.Unit)
+		}
+		if v.Digits == nil || len(v.Digits) > 4 {
+			t.Fatalf("invalid Digits: %v", v.Digits)
+		}
+		for _, d := range v.Digits {
+			if d > 9 {
+				t.Fatalf("Digits out of range: %v", v.Digits)
+			}
+		}
+		if len([]rune(v.Short)) > 2 {
+			t.Fatalf("Short too long: %q", v.Short)
+		}
+		if v.Never != nil || v.Skip != nil {
+			t.Fatalf("field set: %+v", v)
+		}
+		if v.Always == nil || *v.Always < 10 || *v.Always > 20 {
+			t.Fatalf("invalid Always: %v", v.Always)
+		}
+	}
+}
+
+// ranges wider than MaxFloat64 give finite values on the whole range
+func TestWideFloat(t *testing.T) {
+	var v struct {
+		F float64 `valuegen:"min=-1.7976931348623157e308,max=1.7976931348623157e308"`
+		G float32 `valuegen:"min=-3.4028234663852886e38,max=3.4028234663852886e38"`
+	}
+	g := valuegen.New(11, nil)
+	neg, pos := 0, 0
+	for i := 0; i < 1000; i++ {
+		g.Fill(&v)
+		if math.IsInf(v.F, 0) || math.IsNaN(v.F) || math.IsInf(float64(v.G), 0) || math.IsNaN(float64(v.G)) {
+			t.Fatalf("invalid value for iteration %d: %v, %v", i, v.F, v.G)
+		}
+		if v.F < 0 {
+			neg++
+		} else {
+			pos++
+		}
+	}
+	if neg < 400 || pos < 400 {
+		t.Errorf("biased values: %d negative, %d positive", neg, pos)
+	}
+}
+
+func TestInvalidTag(t *testing.T) {
+	for _, v := range []interface{}{
+		&struct {
+			A int8 `valuegen:"min=0,max=300"`
+		}{},
+		&struct {
+			A int `valuegen:"min=0"`
+		}{},
+		&struct {
+			A int `valuegen:"size=3"`
+		}{},
+		&struct {
+			A float64 `valuegen:"min=-inf,max=inf"`
+		}{},
+		&struct {
+			A float32 `valuegen:"min=0,max=+Inf"`
+		}{},
+		&struct {
+			A float64 `valuegen:"min=NaN,max=1"`
+		}{},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("no panic for %T", v)
+				}
+			}()
+			valuegen.New(0, nil).Fill(v)
+		}()
+	}
+}
+
+// recursive types end at MaxDepth
+func TestMaxDepth(t *testing.T) {
+	type list struct {
+		Next *list `valuegen:"nil=0"`
+	}
+	cfg := valuegen.DefaultConfig
+	cfg.MaxDepth = 5
+	var l list
+	valuegen.New(0, &cfg).Fill(&l)
+	n := 0
+	for p := l.Next; p != nil; p = p.Next {
+		n++
+	}
+	if n != cfg.MaxDepth {
+		t.Errorf("invalid depth: expected %d, actual %d", cfg.MaxDepth, n)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	cfg := valuegen.Config{MaxLen: 3, MaxDepth: 2, NilProb: 0, Alphabet: "ab"}
+	g := valuegen.New(7, &cfg)
+	for i := 0; i < 100; i++ {
+		var v map[string][]string
+		g.Fill(&v)
+		if v == nil || len(v) > 3 {
+			t.Fatalf("invalid map: %v", v)
+		}
+		for k, s := range v {
+			if s == nil || len(s) > 3 || strings.Trim(k, "ab") != "" {
+				t.Fatalf("invalid entry: %q, %q", k, s)
+			}
+		}
+	}
+}
+
+type even int
+
+func (e *even) Generate(g *valuegen.Gen) {
+	*e = even(g.Intn(100) * 2)
+}
+
+func TestCustom(t *testing.T) {
+	g := valuegen.New(3, nil)
+	g.Register(reflect.TypeOf(time.Time{}), func(g *valuegen.Gen) reflect.Value {
+		return reflect.ValueOf(time.Unix(int64(g.Intn(1<<30)), 0))
+	})
+	for i := 0; i < 100; i++ {
+		var v struct {
+			E  [4]even
+			At time.Time
+		}
+		g.Fill(&v)
+		for _, e := range v.E {
+			if e%2 != 0 || e < 0 || e >= 200 {
+				t.Fatalf("invalid value by Generator: %d", e)
+			}
+		}
+		if v.At.IsZero() {
+			t.Fatalf("registered function not used")
+		}
+	}
+}
+
+func TestQuick(t *testing.T) {
+	f := func(s []int16, m map[uint8]string) bool {
+		return len(s) <= valuegen.DefaultConfig.MaxLen && len(m) <= valuegen.DefaultConfig.MaxLen
+	}
+	g := valuegen.New(uint64(time.Now().UnixNano()), nil)
+	if err := quick.Check(f, &quick.Config{Values: g.QuickValues(f)}); err != nil {
+		t.Errorf("seed %d: %v", g.Seed(), err)
+	}
+}
@@ -0,0 +1,99 @@
+package mtrand_test
+
+import (
+	"bytes"
+	mrand "math/rand"
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+)
+
+// check outputs of WELL generators initialized by a state array.
+// Reference outputs of the authors' build were not available. The values were captured from a C
+// transcription of the authors' WELL*.c, in their original macros and case_1..case_6 state machines, compiled with gcc;
+// the state machines cover the wrap-around of the indices that this package computes by modulo.
+func TestWELL(t *testing.T) {
+
+	// outputs 0 to 2 and 4995 to 4999 from the state {1, 2, 3, ...}
+	target := []struct {
+		name        string
+		gen         func() *mtrand.WELL
+		first, last []uint32
+	}{
+		{"WELL512a", mtrand.NewWELL512a,
+			[]uint32{0xa07c007a, 0x91dc0d3a, 0x2cd8253e},
+			[]uint32{0xd24bd175, 0xccb09081, 0x65957312, 0x14095926, 0x57de84d3}},
+		{"WELL1024a", mtrand.NewWELL1024a,
+			[]uint32{0x58c982b7, 0x6cc8e0b9, 0x4001cd3b},
+			[]uint32{0x3f733256, 0x8f6c642c, 0x75c89d5f, 0x91dc8306, 0x260b4e79}},
+		{"WELL19937a", mtrand.NewWELL19937a,
+			[]uint32{0x24608e7f, 0xe53c76bd, 0x5cedf694},
+			[]uint32{0x073c3c26, 0x578db38d, 0xc7ac1a33, 0xffda304b, 0x911cf45d}},
+		{"WELL19937c", mtrand.NewWELL19937c,
+			[]uint32{0x0ca0197f, 0x7110e0bd, 0xa285f494},
+			[]uint32{0x90b02f26, 0x0943358d, 0x02208b33, 0x0156b54b, 0x0c70725d}},
+		{"WELL44497a", mtrand.NewWELL44497a,
+			[]uint32{0x243db540, 0x2825b90b, 0x2c2dbb72},
+			[]uint32{0xbde344ba, 0x0c5788a9, 0xce779951, 0x3330a30d, 0x65133e56}},
+		{"WELL44497b", mtrand.NewWELL44497b,
+			[]uint32{0xece5b540, 0xe0f83d0b, 0x6ee1ab72},
+			[]uint32{0x847250ba, 0xc5831ca9, 0x15bb1951, 0x7161270d, 0xfe8f3e56}},
+	}
+
+	for _, c := range target {
+		w := c.gen()
+		init := make([]uint32, w.StateSize())
+		for i := range init {
+			init[i] = uint32(i + 1)
+		}
+		w.InitByState(init)
+		for i := 0; i < 5000; i++ {
+			r := w.GenUint32()
+			var v uint32
+			switch {
+			case i < len(c.first):
+				v = c.first[i]
+			case i >= 5000-len(c.last):
+				v = c.last[i-(5000-len(c.last))]
+			default:
+				continue
+			}
+			if r != v {
+				t.Errorf("%s: invalid value for iteration %d: expected %x, actual %x", c.name, i, v, r)
+			}
+		}
+	}
+}
+
+// test interfaces with go standard lib
+func TestWELLInterface(t *testing.T) {
+
+	// math/rand with the same seed must give the same sequence
+	r1, r2 := mrand.New(mtrand.NewWELL19937c()), mrand.New(mtrand.NewWELL19937c())
+	r1.Seed(1)
+	r2.Seed(1)
+	for i := 0; i < 1000; i++ {
+		a, b := r1.Int63(), r2.Int63()
+		if a != b {
+			t.Fatalf("invalid value for iteration %d: expected %x, actual %x", i, a, b)
+		}
+	}
+
+	// io.Reader gives the generated words in little endian
+	w1, w2 := mtrand.NewWELL1024a(), mtrand.NewWELL1024a()
+	w1.Init(1)
+	w2.Init(1)
+	buf := make([]byte, 10)
+	n, err := w1.Read(buf)
+	if n != len(buf) || err != nil {
+		t.Fatalf("Read() failed: %d, %v", n, err)
+	}
+	expected := make([]byte, 0, 12)
+	for i := 0; i < 3; i++ {
+		u := w2.GenUint32()
+		expected = append(expected, byte(u), byte(u>>8), byte(u>>16), byte(u>>24))
+	}
+	if !bytes.Equal(buf, expected[:10]) {
+		t.Errorf("invalid Read() result: expected %x, actual %x", expected[:10], buf)
+	}
+}
@@ -0,0 +1,257 @@
+/*
+	xsadd.go
+	a translation of XORSHIFT-ADD (XSadd) random number generator
+	by Mutsuo Saito and Makoto Matsumoto, "xsadd.c"
+
+	See http://www.math.sci.hiroshima-u.ac.jp/m-mat/MT/XSADD/index.html
+	for original C source code and tech info.
+
+	The original jump function uses a precomputed characteristic polynomial.
+	Here the polynomial is computed once from the generator itself, by Berlekamp-Massey algorithm.
+*/
+
+package mtrand
+
+import (
+	"sync"
+)
+
+const (
+	xsaddLoop = 8
+	xsaddSh1  = 15
+	xsaddSh2  = 18
+	xsaddSh3  = 11
+)
+
+// XORSHIFT-ADD random generator, with period 2^128-1
+type XSadd struct {
+	state [4]uint32
+	init  bool // true if the state is initialized
+}
+
+// NewXSadd() creates a new XORSHIFT-ADD random generator
+func NewXSadd() *XSadd {
+	return &XSadd{}
+}
+
+func (x *XSadd) nextState() {
+	t := x.state[0]
+	t ^= t << xsaddSh1
+	t ^= t >> xsaddSh2
+	t ^= x.state[3] << xsaddSh3
+	x.state[0] = x.state[1]
+	x.state[1] = x.state[2]
+	x.state[2] = x.state[3]
+	x.state[3] = t
+}
+
+// avoid the all-zero state
+func (x *XSadd) periodCertification() {
+	if x.state[0] == 0 && x.state[1] == 0 && x.state[2] == 0 && x.state[3] == 0 {
+		x.state = [4]uint32{'X', 'S', 'A', 'D'}
+	}
+}
+
+// init the state with a seed
+func (x *XSadd) Init(seed uint32) {
+	x.state = [4]uint32{seed, 0, 0, 0}
+	for i := uint32(1); i < xsaddLoop; i++ {
+		x.state[i&3] ^= i + 1812433253*(x.state[(i-1)&3]^(x.state[(i-1)&3]>>30))
+	}
+	x.periodCertification()
+	for i := 0; i < xsaddLoop; i++ {
+		x.nextState()
+	}
+	x.init = true
+}
+
+func xsaddIniFunc1(x uint32) uint32 { return (x ^ (x >> 27)) * 1664525 }
+func xsaddIniFunc2(x uint32) uint32 { return (x ^ (x >> 27)) * 1566083941 }
+
+// init with an array
+func (x *XSadd) InitByArray(key []uint32) {
+	const (
+		lag  = 1
+		mid  = 1
+		size = 4
+	)
+	st := &x.state
+	*st = [4]uint32{}
+
+	keylen := len(key)
+	count := xsaddLoop
+	if keylen+1 > xsaddLoop {
+		count = keylen + 1
+	}
+
+	r := xsaddIniFunc1(st[0] ^ st[mid%size] ^ st[(size-1)%size])
+	st[mid%size] += r
+	r += uint32(keylen)
+	st[(mid+lag)%size] += r
+	st[0] = r
+	count--
+
+	i, j := 1, 0
+	for ; j < count && j < keylen; j++ {
+		r = xsaddIniFunc1(st[i%size] ^ st[(i+mid)%size] ^ st[(i+size-1)%size])
+		st[(i+mid)%size] += r
+		r += key[j] + uint32(i)
+		st[(i+mid+lag)%size] += r
+		st[i%size] = r
+		i = (i + 1) % size
+	}
+	for ; j < count; j++ {
+		r = xsaddIniFunc1(st[i%size] ^ st[(i+mid)%size] ^ st[(i+size-1)%size])
+		st[(i+mid)%size] += r
+		r += uint32(i)
+		st[(i+mid+lag)%size] += r
+		st[i%size] = r
+		i = (i + 1) % size
+	}
+	for j = 0; j < size; j++ {
+		r = xsaddIniFunc2(st[i%size] + st[(i+mid)%size] + st[(i+size-1)%size])
+		st[(i+mid)%size] ^= r
+		r -= uint32(i)
+		st[(i+mid+lag)%size] ^= r
+		st[i%size] = r
+		i = (i + 1) % size
+	}
+
+	x.periodCertification()
+	for i := 0; i < xsaddLoop; i++ {
+		x.nextState()
+	}
+	x.init = true
+}
+
+// generates a random number on [0,0xffffffff]-interval
+func (x *XSadd) GenUint32() uint32 {
+	if !x.init { // if the state has not been initialized,
+		x.Init(5489) // a default initial seed is used
+	}
+	x.nextState()
+	return x.state[3] + x.state[2]
+}
+
+// generates a random number on [0,0x7fffffff]-interval
+func (x *XSadd) GenInt31() int32 {
+	return int32(x.GenUint32() >> 1)
+}
+
+// generates a random number on [0,1]-real-interval
+func (x *XSadd) GenReal1() float64 {
+	// divided by 2^32-1
+	return float64(x.GenUint32()) * (1.0 / 4294967295.0)
+}
+
+// generates a random number on [0,1)-real-interval
+func (x *XSadd) GenReal2() float64 {
+	// divided by 2^32
+	return float64(x.GenUint32()) * (1.0 / 4294967296.0)
+}
+
+// generates a random number on (0,1)-real-interval
+func (x *XSadd) GenReal3() float64 {
+	// divided by 2^32
+	return (float64(x.GenUint32()) + 0.5) * (1.0 / 4294967296.0)
+}
+
+// generates a random number on [0,1) with 53-bit resolution
+func (x *XSadd) GenRes53() float64 {
+	a, b := x.GenUint32()>>5, x.GenUint32()>>6
+	return (float64(a)*67108864.0 + float64(b)) * (1.0 / 9007199254740992.0)
+}
+
+//
+// jump
+//
+
+// a polynomial over GF(2) of degree < 128; bit i is the coefficient of x^i
+type xsaddPoly [2]uint64
+
+var (
+	xsaddCharPolyOnce sync.Once
+	xsaddCharPoly     xsaddPoly // the characteristic polynomial, without the x^128 term
+)
+
+// compute the characteristic polynomial of the state transition
+// from the least significant bits of a state word, by Berlekamp-Massey algorithm
+func xsaddCalcCharPoly() {
+	const n = 256
+	x := &XSadd{state: [4]uint32{1, 0, 0, 0}}
+	s := make([]uint8, n)
+	for i := range s {
+		x.nextState()
+		s[i] = uint8(x.state[3] & 1)
+	}
+
+	c, l := berlekampMassey(s)
+	if l != 128 {
+		panic("mtrand: unexpected degree of XSadd characteristic polynomial")
+	}
+	// the characteristic polynomial is the reciprocal of the connection polynomial
+	for i := 1; i <= l; i++ {
+		if c[i] != 0 {
+			e := l - i
+			xsaddCharPoly[e/64] |= 1 << (e % 64)
+		}
+	}
+}
+
+// multiply p by x, modulo the characteristic polynomial
+func (p *xsaddPoly) mulX() {
+	carry := p[1] >> 63
+	p[1] = p[1]<<1 | p[0]>>63
+	p[0] <<= 1
+	if carry != 0 {
+		p[0] ^= xsaddCharPoly[0]
+		p[1] ^= xsaddCharPoly[1]
+	}
+}
+
+// multiply two polynomials, modulo the characteristic polynomial
+func xsaddPolyMulMod(a, b xsaddPoly) (r xsaddPoly) {
+	for i := 127; i >= 0; i-- {
+		r.mulX()
+		if b[i/64]>>(i%64)&1 != 0 {
+			r[0] ^= a[0]
+			r[1] ^= a[1]
+		}
+	}
+	return
+}
+
+// Jump() advances the state by (mulStep * 2^baseStep) steps, like xsadd_jump() of the original
+func (x *XSadd) Jump(mulStep, baseStep uint32) {
+	if !x.init {
+		x.Init(5489)
+	}
+	xsaddCharPolyOnce.Do(xsaddCalcCharPoly)
+
+	// base = x^(2^baseStep)
+	base := xsaddPoly{2, 0}
+	for i := uint32(0); i < baseStep; i++ {
+		base = xsaddPolyMulMod(base, base)
+	}
+	// jp = base^mulStep
+	jp := xsaddPoly{1, 0}
+	for ; mulStep > 0; mulStep >>= 1 {
+		if mulStep&1 != 0 {
+			jp = xsaddPolyMulMod(jp, base)
+		}
+		base = xsaddPolyMulMod(base, base)
+	}
+
+	// evaluate jp(A) on the state, by Horner's method
+	orig := x.state
+	x.state = [4]uint32{}
+	for i := 127; i >= 0; i-- {
+		x.nextState()
+		if jp[i/64]>>(i%64)&1 != 0 {
+			x.state[0] ^= orig[0]
+			x.state[1] ^= orig[1]
+			x.state[2] ^= orig[2]
+			x.state[3] ^= orig[3]
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package mtrand_test
+
+import (
+	"testing"
+
+	mtrand "github.com/mixcode/golib-mtrand"
+)
+
+// compare generated numbers with known outputs
+func TestXSadd(t *testing.T) {
+
+	// first outputs of Init(1234)
+	target1 := []uint32{1823491521, 1658333335, 1467485721, 45623648, 3336175492}
+	x := mtrand.NewXSadd()
+	x.Init(1234)
+	for i, v := range target1 {
+		r := x.GenUint32()
+		if r != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d", i, v, r)
+		}
+	}
+
+	// init by array
+	target2 := []uint32{2548027467, 3097333032, 1539620205, 3254868056, 3132842957}
+	x.InitByArray([]uint32{0x123, 0x234, 0x345, 0x456})
+	for i, v := range target2 {
+		r := x.GenUint32()
+		if r != v {
+			t.Errorf("invalid value for iteration %d: expected %d, actual %d", i, v, r)
+		}
+	}
+}
+
+// jumping must be the same as generating numbers
+func TestXSaddJump(t *testing.T) {
+	steps := []struct{ mul, base uint32 }{
+		{0, 0}, {1, 0}, {3, 0}, {1, 4}, {5, 3}, {127, 0}, {1000, 2},
+	}
+	for _, s := range steps {
+		x1, x2 := mtrand.NewXSadd(), mtrand.NewXSadd()
+		x1.Init(1)
+		x2.Init(1)
+		for i := 0; i < int(s.mul)<<s.base; i++ {
+			x1.GenUint32()
+		}
+		x2.Jump(s.mul, s.base)
+		for i := 0; i < 10; i++ {
+			v, r := x1.GenUint32(), x2.GenUint32()
+			if r != v {
+				t.Errorf("jump %d*2^%d: invalid value for iteration %d: expected %d, actual %d", s.mul, s.base, i, v, r)
+			}
+		}
+	}
+}